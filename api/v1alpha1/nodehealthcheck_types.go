@@ -17,8 +17,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -34,13 +37,74 @@ const (
 	ConditionReasonDisabledTemplateInvalid = "RemediationTemplateInvalid"
 	// ConditionReasonEnabled is the condition reason for type Disabled and status False
 	ConditionReasonEnabled = "NodeHealthCheckEnabled"
+
+	// ConditionTypeSelectorOverlap is the condition type used when nodes are selected by other NHCs as well
+	ConditionTypeSelectorOverlap = "SelectorOverlap"
+	// ConditionReasonSelectorOverlapDetected is the condition reason for type SelectorOverlap and status True
+	ConditionReasonSelectorOverlapDetected = "OverlappingSelectorDetected"
+	// ConditionReasonNoSelectorOverlap is the condition reason for type SelectorOverlap and status False
+	ConditionReasonNoSelectorOverlap = "NoOverlappingSelector"
+
+	// ConditionTypeRemediationBlocked is the condition type used when remediation is blocked by the safety limits
+	ConditionTypeRemediationBlocked = "RemediationBlocked"
+	// ConditionReasonMinHealthyNotMet is the condition reason for type RemediationBlocked when there are less
+	// healthy nodes than MinHealthy
+	ConditionReasonMinHealthyNotMet = "MinHealthyNotMet"
+	// ConditionReasonOutsideUnhealthyRange is the condition reason for type RemediationBlocked when the number of
+	// unhealthy nodes is outside of the UnhealthyRange
+	ConditionReasonOutsideUnhealthyRange = "OutsideUnhealthyRange"
+	// ConditionReasonMaxUnhealthyReached is the condition reason for type RemediationBlocked when new remediations
+	// are postponed because MaxUnhealthy nodes are remediated already
+	ConditionReasonMaxUnhealthyReached = "MaxUnhealthyReached"
+	// ConditionReasonRateLimitExceeded is the condition reason for type RemediationBlocked when new remediations
+	// are postponed because the RemediationRateLimit is exceeded
+	ConditionReasonRateLimitExceeded = "RateLimitExceeded"
+	// ConditionReasonRemediationBudgetExhausted is the condition reason for type RemediationBlocked when new
+	// remediations are postponed because the cluster-wide RemediationBudget is exhausted
+	ConditionReasonRemediationBudgetExhausted = "RemediationBudgetExhausted"
+	// ConditionReasonMaxRemediationsPerDomainReached is the condition reason for type RemediationBlocked when new
+	// remediations are postponed because MaxRemediationsPerDomain nodes of their failure domain are remediated already
+	ConditionReasonMaxRemediationsPerDomainReached = "MaxRemediationsPerDomainReached"
+	// ConditionReasonRemediationAllowed is the condition reason for type RemediationBlocked and status False
+	ConditionReasonRemediationAllowed = "RemediationAllowed"
+
+	// ConditionTypeStormDetected is the condition type used when remediation is paused because of a storm of
+	// unhealthy nodes
+	ConditionTypeStormDetected = "StormDetected"
+	// ConditionReasonStormThresholdExceeded is the condition reason for type StormDetected when more nodes
+	// became unhealthy within the window than MaxNewUnhealthyNodes
+	ConditionReasonStormThresholdExceeded = "NewUnhealthyNodesThresholdExceeded"
+	// ConditionReasonStormStabilizing is the condition reason for type StormDetected when the storm is waiting for
+	// the StabilizationPeriod to pass
+	ConditionReasonStormStabilizing = "WaitingForStabilization"
+	// ConditionReasonNoStorm is the condition reason for type StormDetected and status False
+	ConditionReasonNoStorm = "NoStormDetected"
+)
+
+const (
+	// NodeExcludeAnnotationKey is the annotation key for excluding a node from remediation by all NodeHealthChecks.
+	// The value is either empty, or a RFC3339 timestamp until which the node is excluded.
+	NodeExcludeAnnotationKey = "remediation.medik8s.io/exclude"
+
+	// NodeOverrideAnnotationKey is the annotation key for a one-time manual override of the node's remediation.
+	// It is removed from the node after it was handled. Known values are "reevaluate" and "restart-escalation".
+	NodeOverrideAnnotationKey = "remediation.medik8s.io/override"
+	// NodeOverrideReevaluate forgets everything which was observed about the node so far, so that it's evaluated
+	// from scratch. Ongoing remediations are kept.
+	NodeOverrideReevaluate = "reevaluate"
+	// NodeOverrideRestartEscalation deletes the node's remediation CRs, so that its remediation restarts with the
+	// first remediator if it's still unhealthy.
+	NodeOverrideRestartEscalation = "restart-escalation"
 )
 
 // NHCPhase is the string used for NHC.Status.Phase
 type NHCPhase string
 
 const (
-	// PhaseDisabled is used when the Disabled condition is true
+	// PhaseTerminating is used when the NHC is being deleted
+	PhaseTerminating NHCPhase = "Terminating"
+
+	// PhaseDisabled is used when not terminating, and the Disabled condition is true
 	PhaseDisabled NHCPhase = "Disabled"
 
 	// PhasePaused is used when not disabled, but PauseRequests is set
@@ -78,6 +142,86 @@ type NodeHealthCheckSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
 
+	// UnhealthyExpression is an optional CEL expression which identifies unhealthy nodes, in addition to
+	// UnhealthyConditions. It has access to these variables:
+	// "conditions", a list of the node's conditions, with type, status, reason, message and lastTransitionTime fields;
+	// "labels", a map of the node's labels;
+	// "taints", a list of the node's taints, with key, value and effect fields;
+	// "now", the current time.
+	// The expression needs to evaluate to true for unhealthy nodes, e.g.
+	// 'conditions.exists(c, c.type == "Ready" && c.status == "Unknown" && now - c.lastTransitionTime > duration("2m"))'
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyExpression string `json:"unhealthyExpression,omitempty"`
+
+	// StaleLeaseDuration is an optional duration after which a node is considered unhealthy, when its Lease
+	// in the kube-node-lease namespace wasn't renewed. Kubelet renews its Lease regularly, so a stale Lease
+	// indicates a failing node earlier than the node's Ready condition. Nodes without a Lease are ignored.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StaleLeaseDuration *metav1.Duration `json:"staleLeaseDuration,omitempty"`
+
+	// NodeStartupTimeout is an optional duration after which a node is considered unhealthy, when it didn't
+	// report Ready=True since its creation. This catches nodes which join the cluster, but never become Ready.
+	// Nodes whose Ready condition changed after the timeout are handled by the UnhealthyConditions only.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// UnhealthyPods contains a list of pods, which indicate an unhealthy node when they are not ready on it
+	// for the given duration. This is useful for critical DaemonSet pods, e.g. of CNI or storage providers,
+	// which might fail while the node still is ready.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyPods []UnhealthyPod `json:"unhealthyPods,omitempty"`
+
+	// UnhealthyTaints contains a list of taints, which make a node unhealthy when they exist for the given duration.
+	// The duration is measured from the taint's timeAdded field, or from when the taint was seen first if it
+	// has no timeAdded. Examples are the node.kubernetes.io/unreachable taint of the node lifecycle controller,
+	// or taints of vendor specific health checks.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyTaints []UnhealthyTaint `json:"unhealthyTaints,omitempty"`
+
+	// UnhealthySignals contains a list of NodeHealthSignal sources, whose signals make a node unhealthy.
+	// NodeHealthSignals are created by external health agents for reporting node health problems, which
+	// can't be expressed with node conditions. Expired signals are ignored.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthySignals []UnhealthySignal `json:"unhealthySignals,omitempty"`
+
+	// PrometheusQuery optionally configures a PromQL query, whose results identify unhealthy nodes.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PrometheusQuery *PrometheusQuery `json:"prometheusQuery,omitempty"`
+
+	// KubeletProbe optionally configures probing the kubelet of nodes which fail the UnhealthyConditions.
+	// When the kubelet responds, the node is probably only disconnected from the API server, and its remediation
+	// is deferred. When the kubelet doesn't respond, the node is confirmed to be unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	KubeletProbe *KubeletProbe `json:"kubeletProbe,omitempty"`
+
 	// Remediation is allowed if at least "MinHealthy" nodes selected by "selector" are healthy.
 	// Expects either a positive integer value or a percentage value.
 	// Percentage values must be positive whole numbers and are capped at 100%.
@@ -89,6 +233,33 @@ type NodeHealthCheckSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	MinHealthy *intstr.IntOrString `json:"minHealthy,omitempty"`
 
+	// MaxUnhealthy is the maximum number of nodes selected by "selector" which are remediated concurrently.
+	// New remediations are only started while less nodes are under remediation.
+	// Expects either a positive integer value or a percentage value.
+	// Percentage values must be positive whole numbers and are capped at 100%.
+	// 0 is valid and will block all new remediation.
+	//
+	//+optional
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// UnhealthyRange is the allowed range of unhealthy nodes selected by "selector", formatted as "[min-max]".
+	// Remediation is only allowed if the number of unhealthy nodes is within this range, e.g. "[1-5]".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^\\[[0-9]+-[0-9]+\\]$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyRange string `json:"unhealthyRange,omitempty"`
+
+	// Topology optionally configures failure domains, like zones or racks. MinHealthy applies within each domain
+	// instead of all selected nodes then, and the number of concurrent remediations per domain can be limited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Topology *Topology `json:"topology,omitempty"`
+
 	// RemediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -119,8 +290,246 @@ type NodeHealthCheckSpec struct {
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	PauseRequests []string `json:"pauseRequests,omitempty"`
+
+	// DeletionPolicy defines what happens with ongoing remediations when the NodeHealthCheck is deleted.
+	// "Wait" waits for ongoing remediations to finish, without starting new remediations.
+	// "Cancel" deletes the remediation CRs of ongoing remediations.
+	// "Orphan" keeps ongoing remediations running, by removing the owner reference to this NodeHealthCheck
+	// from the remediation CRs.
+	//
+	//+optional
+	//+kubebuilder:default=Wait
+	//+kubebuilder:validation:Enum=Wait;Cancel;Orphan
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// NodeDeletionPolicy defines what happens with leftover remediation CRs of nodes which were deleted, e.g. by
+	// a remediator which replaces machines, or by a scale down. The remediation of deleted nodes is considered
+	// as completed, and they are removed from the status.
+	// "Delete" deletes the remediation CRs.
+	// "Orphan" keeps the remediation CRs, by removing the owner reference to this NodeHealthCheck from them.
+	//
+	//+optional
+	//+kubebuilder:default=Delete
+	//+kubebuilder:validation:Enum=Delete;Orphan
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeDeletionPolicy NodeDeletionPolicy `json:"nodeDeletionPolicy,omitempty"`
+
+	// DeselectedNodePolicy defines what happens with ongoing remediations of nodes which aren't selected by the
+	// selector anymore, e.g. because their labels were changed.
+	// "Finish" keeps the remediation CRs until the node is healthy again, or until the current remediation
+	// timed out, without escalating to other remediators.
+	// "Cancel" deletes the remediation CRs immediately.
+	// In both cases the node is removed from the status afterwards.
+	//
+	//+optional
+	//+kubebuilder:default=Finish
+	//+kubebuilder:validation:Enum=Finish;Cancel
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeselectedNodePolicy DeselectedNodePolicy `json:"deselectedNodePolicy,omitempty"`
+
+	// HealthyStabilizationWindow is the duration for which a node under remediation needs to stay healthy,
+	// before its remediation is stopped by deleting the remediation CR. This prevents restarting remediation
+	// for nodes which only look healthy for a short time.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	HealthyStabilizationWindow *metav1.Duration `json:"healthyStabilizationWindow,omitempty"`
+
+	// FlappingDetection optionally configures detection of nodes which toggle between unhealthy and healthy,
+	// and how to handle them.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	FlappingDetection *FlappingDetection `json:"flappingDetection,omitempty"`
+
+	// RemediationRateLimit optionally limits the number of new remediations which are started within a sliding
+	// time window. Unhealthy nodes exceeding the limit stay queued until the oldest remediation leaves the window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationRateLimit *RemediationRateLimit `json:"remediationRateLimit,omitempty"`
+
+	// StormDetection optionally pauses remediation automatically, when many nodes become unhealthy within a short
+	// time, e.g. because of a network problem. Remediation resumes when no node became unhealthy for a while.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StormDetection *StormDetection `json:"stormDetection,omitempty"`
+}
+
+// NodeDeletionPolicy is the string used for NHC.Spec.NodeDeletionPolicy
+type NodeDeletionPolicy string
+
+const (
+	// NodeDeletionPolicyDelete deletes the remediation CRs of deleted nodes
+	NodeDeletionPolicyDelete NodeDeletionPolicy = "Delete"
+
+	// NodeDeletionPolicyOrphan removes the NHC owner reference from the remediation CRs of deleted nodes
+	NodeDeletionPolicyOrphan NodeDeletionPolicy = "Orphan"
+)
+
+// DeselectedNodePolicy is the string used for NHC.Spec.DeselectedNodePolicy
+type DeselectedNodePolicy string
+
+const (
+	// DeselectedNodePolicyFinish keeps remediating deselected nodes until they are healthy or their remediation timed out
+	DeselectedNodePolicyFinish DeselectedNodePolicy = "Finish"
+
+	// DeselectedNodePolicyCancel deletes the remediation CRs of deselected nodes
+	DeselectedNodePolicyCancel DeselectedNodePolicy = "Cancel"
+)
+
+// ParseUnhealthyRange returns the minimum and maximum of the given unhealthy range, formatted as "[min-max]"
+func ParseUnhealthyRange(unhealthyRange string) (int, int, error) {
+	var min, max int
+	if _, err := fmt.Sscanf(unhealthyRange, "[%d-%d]", &min, &max); err != nil {
+		return 0, 0, fmt.Errorf("invalid unhealthy range %q: %v", unhealthyRange, err)
+	}
+	if min < 0 || min > max {
+		return 0, 0, fmt.Errorf("invalid unhealthy range %q: min must not be negative or greater than max", unhealthyRange)
+	}
+	return min, max, nil
 }
 
+// FlappingDetection defines when a node is flapping, and what happens with flapping nodes
+type FlappingDetection struct {
+	// MaxTransitions is the number of times a node under remediation may become healthy within the window.
+	// When it becomes healthy more often, it's flapping.
+	//
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxTransitions int `json:"maxTransitions"`
+
+	// Window is the duration in which transitions to healthy are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+
+	// Action defines what happens with flapping nodes.
+	// "StopRemediation" doesn't start new remediations for the node while it's flapping.
+	// "Escalate" keeps the node's remediation running while it's flapping, and immediately escalates it to the
+	// next escalating remediation. It can only be used with EscalatingRemediations.
+	//
+	//+optional
+	//+kubebuilder:default=StopRemediation
+	//+kubebuilder:validation:Enum=StopRemediation;Escalate
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Action FlappingAction `json:"action,omitempty"`
+}
+
+// StormDetection defines when many nodes becoming unhealthy are a storm, and when the storm is over
+type StormDetection struct {
+	// MaxNewUnhealthyNodes is the number of selected nodes which may become unhealthy within the window.
+	// When more nodes become unhealthy, a storm is detected and remediation is paused.
+	// Expects either a positive integer value or a percentage value.
+	// Percentage values must be positive whole numbers and are capped at 100%.
+	//
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxNewUnhealthyNodes intstr.IntOrString `json:"maxNewUnhealthyNodes"`
+
+	// Window is the duration in which nodes becoming unhealthy are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+
+	// StabilizationPeriod is the duration in which no node may become unhealthy, before remediation is resumed
+	// after a storm.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StabilizationPeriod metav1.Duration `json:"stabilizationPeriod"`
+}
+
+// Topology defines the failure domains of the selected nodes
+type Topology struct {
+	// Key is the key of the node label, whose values define the failure domains,
+	// e.g. "topology.kubernetes.io/zone". Nodes without this label don't belong to any failure domain.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// MaxRemediationsPerDomain optionally limits the number of nodes which are remediated concurrently within each
+	// failure domain. New remediations are only started while less nodes of the domain are under remediation.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRemediationsPerDomain *int `json:"maxRemediationsPerDomain,omitempty"`
+}
+
+// RemediationRateLimit defines how many new remediations can be started within a sliding time window
+type RemediationRateLimit struct {
+	// MaxRemediations is the maximum number of remediation CRs which are created within the window, including the
+	// CRs of escalating remediations.
+	//
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRemediations int `json:"maxRemediations"`
+
+	// Window is the duration in which new remediations are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+}
+
+// FlappingAction is the string used for FlappingDetection.Action
+type FlappingAction string
+
+const (
+	// FlappingActionStopRemediation doesn't start new remediations for flapping nodes
+	FlappingActionStopRemediation FlappingAction = "StopRemediation"
+
+	// FlappingActionEscalate escalates the remediation of flapping nodes to the next escalating remediation
+	FlappingActionEscalate FlappingAction = "Escalate"
+)
+
+// DeletionPolicy is the string used for NHC.Spec.DeletionPolicy
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyWait waits for ongoing remediations to finish before the NHC is deleted
+	DeletionPolicyWait DeletionPolicy = "Wait"
+
+	// DeletionPolicyCancel deletes the remediation CRs of ongoing remediations
+	DeletionPolicyCancel DeletionPolicy = "Cancel"
+
+	// DeletionPolicyOrphan removes the NHC owner reference from the remediation CRs of ongoing remediations
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 // UnhealthyCondition represents a Node condition type and value with a
 // specified duration. When the named condition has been in the given
 // status for at least the duration value a node is considered unhealthy.
@@ -150,8 +559,253 @@ type UnhealthyCondition struct {
 	//+kubebuilder:validation:Type=string
 	//+operator-sdk:csv:customresourcedefinitions:type=spec
 	Duration metav1.Duration `json:"duration"`
+
+	// AbsentPolicy defines how to treat nodes which don't have a condition of the given type.
+	// "Ignore" skips this condition for these nodes, "Unhealthy" considers these nodes as unhealthy
+	// after the condition is absent for AbsentDuration.
+	// This is useful for custom conditions, e.g. from node-problem-detector, where a missing condition
+	// indicates that the detector doesn't work.
+	//
+	//+optional
+	//+kubebuilder:default=Ignore
+	//+kubebuilder:validation:Enum=Ignore;Unhealthy
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AbsentPolicy AbsentConditionPolicy `json:"absentPolicy,omitempty"`
+
+	// AbsentDuration is the duration for which the condition needs to be absent, before the node is considered
+	// unhealthy. Only used with AbsentPolicy "Unhealthy". Defaults to Duration.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AbsentDuration *metav1.Duration `json:"absentDuration,omitempty"`
 }
 
+// UnhealthyPod defines pods, which make the node they are running on unhealthy when they aren't ready
+// for the given duration. At least one of Selector or DaemonSet needs to be set.
+type UnhealthyPod struct {
+	// Namespace of the pods.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+
+	// Selector is a label selector for the pods.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// DaemonSet is the name of the DaemonSet owning the pods.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DaemonSet string `json:"daemonSet,omitempty"`
+
+	// Duration for which the pod needs to be not ready, before its node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthyTaint defines a taint, which makes a node unhealthy when it exists for the given duration.
+type UnhealthyTaint struct {
+	// Key of the taint.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// Value of the taint. When empty, taints with any value match.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value,omitempty"`
+
+	// Effect of the taint.
+	//
+	//+kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Effect corev1.TaintEffect `json:"effect"`
+
+	// Duration for which the taint needs to exist, before the node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthySignal defines NodeHealthSignals, which make a node unhealthy.
+type UnhealthySignal struct {
+	// Source of the NodeHealthSignals.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Source string `json:"source"`
+
+	// MinSeverity is the minimum severity of NodeHealthSignals, which make a node unhealthy.
+	// One of "Info", "Warning" or "Critical".
+	//
+	//+optional
+	//+kubebuilder:default=Critical
+	//+kubebuilder:validation:Enum=Info;Warning;Critical
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinSeverity SignalSeverity `json:"minSeverity,omitempty"`
+}
+
+// PrometheusQuery defines a PromQL query, whose results identify unhealthy nodes.
+// Each returned series is mapped to a node by a label, and the node is unhealthy when the series' value
+// breaches the threshold for the given duration.
+type PrometheusQuery struct {
+	// URL of the Prometheus compatible HTTP API, e.g. "https://thanos-querier.openshift-monitoring.svc:9091".
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	URL string `json:"url"`
+
+	// Query is the PromQL query, which needs to return an instant vector.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Query string `json:"query"`
+
+	// NodeLabel is the label of the returned series, which contains the node name.
+	//
+	//+optional
+	//+kubebuilder:default=node
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// Operator is used for comparing the series' value with the threshold. The node is unhealthy when the
+	// comparison is true. One of "GreaterThan", "GreaterThanOrEqual", "LessThan" or "LessThanOrEqual".
+	//
+	//+optional
+	//+kubebuilder:default=GreaterThan
+	//+kubebuilder:validation:Enum=GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Operator ThresholdOperator `json:"operator,omitempty"`
+
+	// Threshold is the decimal number the series' value is compared with.
+	//
+	//+kubebuilder:validation:Pattern="^-?[0-9]+(\\.[0-9]+)?$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Threshold string `json:"threshold"`
+
+	// Duration for which the threshold needs to be breached, before the node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+
+	// Interval defines how often the query is run. Query results are cached for this interval.
+	//
+	//+optional
+	//+kubebuilder:default:="30s"
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// InsecureSkipTLSVerify disables verification of the server's certificate.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// UseServiceAccountToken configures the operator to authenticate with its service account token.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	UseServiceAccountToken bool `json:"useServiceAccountToken,omitempty"`
+}
+
+// KubeletProbe defines how the kubelet of a node is probed. The probe connects to the node's InternalIP.
+type KubeletProbe struct {
+	// Port of the kubelet's health endpoint. Defaults to the kubelet port reported in the node's status.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Port *int32 `json:"port,omitempty"`
+
+	// Path of the kubelet's health endpoint.
+	//
+	//+optional
+	//+kubebuilder:default="/healthz"
+	//+kubebuilder:validation:Pattern="^/"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Path string `json:"path,omitempty"`
+
+	// Scheme used for connecting to the kubelet, one of "HTTP" or "HTTPS".
+	// The kubelet's serving certificate isn't verified.
+	//
+	//+optional
+	//+kubebuilder:default=HTTPS
+	//+kubebuilder:validation:Enum=HTTP;HTTPS
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Timeout of the probe.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:default:="5s"
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ThresholdOperator is the string used for PrometheusQuery.Operator
+type ThresholdOperator string
+
+const (
+	// ThresholdOperatorGreaterThan considers values greater than the threshold as unhealthy
+	ThresholdOperatorGreaterThan ThresholdOperator = "GreaterThan"
+	// ThresholdOperatorGreaterThanOrEqual considers values greater than or equal to the threshold as unhealthy
+	ThresholdOperatorGreaterThanOrEqual ThresholdOperator = "GreaterThanOrEqual"
+	// ThresholdOperatorLessThan considers values less than the threshold as unhealthy
+	ThresholdOperatorLessThan ThresholdOperator = "LessThan"
+	// ThresholdOperatorLessThanOrEqual considers values less than or equal to the threshold as unhealthy
+	ThresholdOperatorLessThanOrEqual ThresholdOperator = "LessThanOrEqual"
+)
+
+// AbsentConditionPolicy is the string used for UnhealthyCondition.AbsentPolicy
+type AbsentConditionPolicy string
+
+const (
+	// AbsentConditionPolicyIgnore skips absent conditions
+	AbsentConditionPolicyIgnore AbsentConditionPolicy = "Ignore"
+
+	// AbsentConditionPolicyUnhealthy considers nodes with absent conditions as unhealthy
+	AbsentConditionPolicyUnhealthy AbsentConditionPolicy = "Unhealthy"
+)
+
 // EscalatingRemediation defines a remediation template with order and timeout
 type EscalatingRemediation struct {
 	// RemediationTemplate is a reference to a remediation template
@@ -207,6 +861,31 @@ type NodeHealthCheckStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status
 	UnhealthyNodes []*UnhealthyNode `json:"unhealthyNodes,omitempty"`
 
+	// ExcludedNodes lists the selected nodes which are excluded from remediation by an annotation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ExcludedNodes []ExcludedNode `json:"excludedNodes,omitempty"`
+
+	// FailureDomains reports the remediation budget of each failure domain, when Topology is configured.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// Storm tracks since when nodes are unhealthy for the StormDetection, and the detected storm.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Storm *StormStatus `json:"storm,omitempty"`
+
+	// RemediationRateLimit tracks the remediations counted by the RemediationRateLimit, and the nodes which are
+	// queued because the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationRateLimit *RemediationRateLimitStatus `json:"remediationRateLimit,omitempty"`
+
 	// InFlightRemediations records the timestamp when remediation triggered per node.
 	// Deprecated in favour of UnhealthyNodes.
 	//
@@ -224,7 +903,8 @@ type NodeHealthCheckStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Phase represents the current phase of this Config.
-	// Known phases are Disabled, Paused, Remediating and Enabled, based on:\n
+	// Known phases are Terminating, Disabled, Paused, Remediating and Enabled, based on:\n
+	// - the deletion timestamp\n
 	// - the status of the Disabled condition\n
 	// - the value of PauseRequests\n
 	// - the value of InFlightRemediations
@@ -247,6 +927,13 @@ type UnhealthyNode struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status
 	Name string `json:"name"`
 
+	// UID is the UID of the unhealthy node. It's used for detecting nodes which were replaced by a new node
+	// with the same name.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UID types.UID `json:"uid,omitempty"`
+
 	// Remediations tracks the remediations created for this node
 	//
 	//+patchStrategy=merge
@@ -254,6 +941,170 @@ type UnhealthyNode struct {
 	//+optional
 	//+operator-sdk:csv:customresourcedefinitions:type=status
 	Remediations []*Remediation `json:"remediations,omitempty"`
+
+	// KubeletProbe is the result of the last kubelet probe of this node.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	KubeletProbe *KubeletProbeResult `json:"kubeletProbe,omitempty"`
+
+	// HealthySince is the time since when the node looks healthy again. It's only set when HealthyStabilizationWindow
+	// or FlappingDetection is configured.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+
+	// HealthyTransitions are the times within the FlappingDetection window, when the node became healthy again.
+	// The node is kept in the status without remediations as long as it has transitions.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyTransitions []metav1.Time `json:"healthyTransitions,omitempty"`
+
+	// FlappingSince is the time since when the node is flapping.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	FlappingSince *metav1.Time `json:"flappingSince,omitempty"`
+}
+
+// ExcludedNode defines a node which is excluded from remediation
+type ExcludedNode struct {
+	// Name is the name of the excluded node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Until is the time until which the node is excluded. It isn't set for nodes which are excluded without expiry.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Until *metav1.Time `json:"until,omitempty"`
+}
+
+// StormStatus defines the observed state of the StormDetection
+type StormStatus struct {
+	// UnhealthyNodes are the unhealthy nodes, and since when they are unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyNodes []StormUnhealthyNode `json:"unhealthyNodes,omitempty"`
+
+	// LastUnhealthyTransition is the last time a node became unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastUnhealthyTransition *metav1.Time `json:"lastUnhealthyTransition,omitempty"`
+
+	// DetectedAt is the time when the ongoing storm was detected. It isn't set when there is no storm.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+}
+
+// StormUnhealthyNode defines an unhealthy node tracked by the StormDetection
+type StormUnhealthyNode struct {
+	// Name is the name of the unhealthy node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Since is the time when the node became unhealthy. It isn't set for nodes which were unhealthy already
+	// when the StormDetection was configured, those aren't counted.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+// FailureDomain defines the observed state of a failure domain
+type FailureDomain struct {
+	// Name is the value of the topology label of the domain's nodes
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// ObservedNodes is the number of selected nodes in the domain
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedNodes int `json:"observedNodes"`
+
+	// HealthyNodes is the number of healthy nodes in the domain
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyNodes int `json:"healthyNodes"`
+
+	// MinHealthy is the number of healthy nodes in the domain, which is needed for remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MinHealthy int `json:"minHealthy"`
+
+	// RemediatingNodes is the number of nodes in the domain which are under remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediatingNodes int `json:"remediatingNodes"`
+
+	// AvailableRemediations is the number of new remediations which can be started in the domain.
+	// It isn't set when it's not limited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	AvailableRemediations *int `json:"availableRemediations,omitempty"`
+}
+
+// RemediationRateLimitStatus defines the observed state of the RemediationRateLimit
+type RemediationRateLimitStatus struct {
+	// RecentRemediations are the creation times of the remediation CRs within the window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RecentRemediations []metav1.Time `json:"recentRemediations,omitempty"`
+
+	// QueuedNodes are the names of the unhealthy nodes which wait for remediation because the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	QueuedNodes []string `json:"queuedNodes,omitempty"`
+
+	// NextRemediationAt is the time when the next new remediation can be started, if the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NextRemediationAt *metav1.Time `json:"nextRemediationAt,omitempty"`
+}
+
+// KubeletProbeResultType is the string used for KubeletProbeResult.Result
+type KubeletProbeResultType string
+
+const (
+	// KubeletProbeResultResponding is used when the kubelet responded, and remediation is deferred
+	KubeletProbeResultResponding KubeletProbeResultType = "Responding"
+
+	// KubeletProbeResultNotResponding is used when the kubelet didn't respond, which confirms that the node is unhealthy
+	KubeletProbeResultNotResponding KubeletProbeResultType = "NotResponding"
+)
+
+// KubeletProbeResult is the result of probing the kubelet of an unhealthy node
+type KubeletProbeResult struct {
+	// Result is "Responding" when the kubelet responded and the node's remediation is deferred,
+	// or "NotResponding" when the kubelet didn't respond and the node is confirmed to be unhealthy.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Result KubeletProbeResultType `json:"result"`
+
+	// Since is the time of the first probe with this result. Repeated probes with the same result don't
+	// update the status.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+
+	// Message contains details about the result
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
 }
 
 // Remediation defines a remediation which was created for a node
@@ -279,6 +1130,7 @@ type Remediation struct {
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:path=nodehealthchecks,scope=Cluster,shortName=nhc
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // NodeHealthCheck is the Schema for the nodehealthchecks API
 //
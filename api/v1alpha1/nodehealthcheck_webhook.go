@@ -17,19 +17,39 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/medik8s/node-healthcheck-operator/pkg/expression"
 )
 
 const (
@@ -37,14 +57,42 @@ const (
 	WebhookCertName = "apiserver.crt"
 	WebhookKeyName  = "apiserver.key"
 
-	OngoingRemediationError   = "prohibited due to running remediation"
-	minHealthyError           = "MinHealthy must not be negative"
-	invalidSelectorError      = "Invalid selector"
-	missingSelectorError      = "Selector is mandatory"
-	mandatoryRemediationError = "Either RemediationTemplate or at least one EscalatingRemediations must be set"
-	mutualRemediationError    = "RemediationTemplate and EscalatingRemediations usage is mutual exclusive"
-	uniqueOrderError          = "EscalatingRemediation Order must be unique"
-	minimumTimeoutError       = "EscalatingRemediation Timeout must be at least one minute"
+	OngoingRemediationError     = "prohibited due to running remediation"
+	minHealthyError             = "MinHealthy must not be negative"
+	maxUnhealthyError           = "MaxUnhealthy must not be negative"
+	unhealthyRangeError         = "UnhealthyRange is invalid"
+	invalidSelectorError        = "Invalid selector"
+	missingSelectorError        = "Selector is mandatory"
+	mandatoryRemediationError   = "Either RemediationTemplate or at least one EscalatingRemediations must be set"
+	mutualRemediationError      = "RemediationTemplate and EscalatingRemediations usage is mutual exclusive"
+	uniqueOrderError            = "EscalatingRemediation Order must be unique"
+	minimumTimeoutError         = "EscalatingRemediation Timeout must be at least one minute"
+	templateKindNotServedError  = "RemediationTemplate kind is not served by the cluster"
+	invalidTemplateError        = "RemediationTemplate is invalid"
+	templateNotFoundWarning     = "RemediationTemplate not found, remediation will be disabled until it exists"
+	overlappingSelectorError    = "Selector selects nodes which are already selected by another NodeHealthCheck"
+	invalidExpressionError      = "UnhealthyExpression is invalid"
+	invalidUnhealthyPodError    = "UnhealthyPod is invalid"
+	invalidPrometheusQueryError = "PrometheusQuery is invalid"
+	flappingEscalationError     = "FlappingDetection action Escalate needs EscalatingRemediations"
+	invalidTopologyKeyError     = "Topology key is not a valid label key"
+	stormDetectionError         = "StormDetection MaxNewUnhealthyNodes must not be negative"
+	overlappingSelectorWarning  = "Selector might select nodes which are selected by another NodeHealthCheck in future"
+
+	validatingWebhookPath = "/validate-remediation-medik8s-io-v1alpha1-nodehealthcheck"
+
+	metal3RemediationTemplateKind = "Metal3RemediationTemplate"
+	templateSuffix                = "Template"
+	machineAPINamespace           = "openshift-machine-api"
+
+	// deploymentNamespaceEnvVar is the env var which holds the namespace the operator is deployed in.
+	// Template refs without namespace default to that namespace.
+	deploymentNamespaceEnvVar = "DEPLOYMENT_NAMESPACE"
+)
+
+var (
+	// DefaultMinHealthy is used when MinHealthy isn't set
+	DefaultMinHealthy = intstr.FromString("51%")
 )
 
 // log is for logging in this package.
@@ -70,13 +118,147 @@ func (nhc *NodeHealthCheck) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		nodehealthchecklog.Info("OLM injected certs for webhooks not found")
 	}
 
+	// The validating webhook needs a client, and it returns warnings, which isn't supported by webhook.Validator.
+	// So register it manually, the builder skips paths which are registered already.
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(validatingWebhookPath, &webhook.Admission{
+		Handler: &customValidator{
+			client:  mgr.GetClient(),
+			decoder: decoder,
+		},
+	})
+
+	// this also registers the conversion webhook, as long as all versions were added to the manager's scheme
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(nhc).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-remediation-medik8s-io-v1alpha1-nodehealthcheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=create;update,versions=v1alpha1,name=mnodehealthcheck.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &NodeHealthCheck{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (nhc *NodeHealthCheck) Default() {
+	nodehealthchecklog.Info("default", "name", nhc.Name)
+	nhc.defaultTemplateNamespaces()
+	nhc.defaultMinHealthy()
+	nhc.defaultEscalatingRemediationsOrder()
+}
+
+func (nhc *NodeHealthCheck) defaultTemplateNamespaces() {
+	ns, found := os.LookupEnv(deploymentNamespaceEnvVar)
+	if !found || ns == "" {
+		// nothing we can default to
+		return
+	}
+	setNamespace := func(ref *corev1.ObjectReference) {
+		if ref != nil && ref.Namespace == "" {
+			ref.Namespace = ns
+		}
+	}
+	setNamespace(nhc.Spec.RemediationTemplate)
+	for i := range nhc.Spec.EscalatingRemediations {
+		setNamespace(&nhc.Spec.EscalatingRemediations[i].RemediationTemplate)
+	}
+}
+
+func (nhc *NodeHealthCheck) defaultMinHealthy() {
+	if nhc.Spec.MinHealthy == nil {
+		minHealthy := DefaultMinHealthy
+		nhc.Spec.MinHealthy = &minHealthy
+		return
+	}
+	// the API allows plain numbers as string, but the controller only understands them as integers
+	if nhc.Spec.MinHealthy.Type == intstr.String {
+		if val, err := strconv.Atoi(nhc.Spec.MinHealthy.StrVal); err == nil {
+			minHealthy := intstr.FromInt(val)
+			nhc.Spec.MinHealthy = &minHealthy
+		}
+	}
+}
+
+func (nhc *NodeHealthCheck) defaultEscalatingRemediationsOrder() {
+	sort.SliceStable(nhc.Spec.EscalatingRemediations, func(i, j int) bool {
+		return nhc.Spec.EscalatingRemediations[i].Order < nhc.Spec.EscalatingRemediations[j].Order
+	})
+}
+
 //+kubebuilder:webhook:path=/validate-remediation-medik8s-io-v1alpha1-nodehealthcheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=create;update;delete,versions=v1alpha1,name=vnodehealthcheck.kb.io,admissionReviewVersions=v1
 
+// customValidator validates NodeHealthChecks. It runs the checks implemented by webhook.Validator on NodeHealthCheck,
+// and additionally the checks which need to look up other resources.
+type customValidator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &customValidator{}
+
+// Handle implements admission.Handler
+func (v *customValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	nhc := &NodeHealthCheck{}
+	var oldNhc *NodeHealthCheck
+	var err error
+	var checkTemplates, checkSelector bool
+	switch req.Operation {
+	case admissionv1.Create:
+		if err = v.decoder.DecodeRaw(req.Object, nhc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		err = nhc.ValidateCreate()
+		checkTemplates = true
+		checkSelector = true
+	case admissionv1.Update:
+		oldNhc = &NodeHealthCheck{}
+		if err = v.decoder.DecodeRaw(req.Object, nhc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err = v.decoder.DecodeRaw(req.OldObject, oldNhc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		err = nhc.ValidateUpdate(oldNhc)
+		// don't block unrelated updates, e.g. pausing, because of templates which became invalid
+		// or other NHCs which were created in the meantime
+		checkTemplates = nhc.isTemplateUpdated(oldNhc)
+		checkSelector = !reflect.DeepEqual(nhc.Spec.Selector, oldNhc.Spec.Selector)
+	case admissionv1.Delete:
+		// OldObject contains the object being deleted
+		if err = v.decoder.DecodeRaw(req.OldObject, nhc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		err = nhc.ValidateDelete()
+	default:
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("unknown operation request %q", req.Operation))
+	}
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	var warnings []string
+	var errs []error
+	if checkTemplates {
+		templateWarnings, err := nhc.validateTemplates(ctx, v.client)
+		warnings = append(warnings, templateWarnings...)
+		errs = append(errs, err)
+	}
+	if checkSelector {
+		selectorWarnings, err := nhc.validateSelectorOverlap(ctx, v.client)
+		warnings = append(warnings, selectorWarnings...)
+		errs = append(errs, err)
+		if oldNhc != nil {
+			errs = append(errs, nhc.validateSelectorUpdateDuringRemediation(ctx, v.client, oldNhc))
+		}
+	}
+	if err := errors.NewAggregate(errs); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
 var _ webhook.Validator = &NodeHealthCheck{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -94,11 +276,9 @@ func (nhc *NodeHealthCheck) ValidateUpdate(old runtime.Object) error {
 		return err
 	}
 
-	// during ongoing remediations, some updates are forbidden
+	// during ongoing remediations, updates which would orphan remediation CRs are forbidden
 	if nhc.isRemediating() {
-		if updated, field := nhc.isRestrictedFieldUpdated(old.(*NodeHealthCheck)); updated {
-			return fmt.Errorf("%s update %s", field, OngoingRemediationError)
-		}
+		return nhc.validateUpdateDuringRemediation(old.(*NodeHealthCheck))
 	}
 	return nil
 }
@@ -106,18 +286,24 @@ func (nhc *NodeHealthCheck) ValidateUpdate(old runtime.Object) error {
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (nhc *NodeHealthCheck) ValidateDelete() error {
 	nodehealthchecklog.Info("validate delete", "name", nhc.Name)
-	if nhc.isRemediating() {
-		return fmt.Errorf("deletion %s", OngoingRemediationError)
-	}
+	// ongoing remediations are handled by the controller, depending on the deletion policy
 	return nil
 }
 
 func (nhc *NodeHealthCheck) validate() error {
 	aggregated := errors.NewAggregate([]error{
 		nhc.validateMinHealthy(),
+		nhc.validateMaxUnhealthy(),
+		nhc.validateUnhealthyRange(),
 		nhc.validateSelector(),
 		nhc.validateMutualRemediations(),
 		nhc.validateEscalatingRemediations(),
+		nhc.validateUnhealthyExpression(),
+		nhc.validateUnhealthyPods(),
+		nhc.validatePrometheusQuery(),
+		nhc.validateFlappingDetection(),
+		nhc.validateTopology(),
+		nhc.validateStormDetection(),
 	})
 
 	// everything else should have been covered by API server validation
@@ -137,6 +323,24 @@ func (nhc *NodeHealthCheck) validateMinHealthy() error {
 	return nil
 }
 
+func (nhc *NodeHealthCheck) validateMaxUnhealthy() error {
+	// Using Minimum kubebuilder marker for IntOrStr does not work (yet)
+	if nhc.Spec.MaxUnhealthy != nil && nhc.Spec.MaxUnhealthy.Type == intstr.Int && nhc.Spec.MaxUnhealthy.IntVal < 0 {
+		return fmt.Errorf("%s: %v", maxUnhealthyError, nhc.Spec.MaxUnhealthy)
+	}
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validateUnhealthyRange() error {
+	if nhc.Spec.UnhealthyRange == "" {
+		return nil
+	}
+	if _, _, err := ParseUnhealthyRange(nhc.Spec.UnhealthyRange); err != nil {
+		return fmt.Errorf("%s: %v", unhealthyRangeError, err)
+	}
+	return nil
+}
+
 func (nhc *NodeHealthCheck) validateSelector() error {
 	if len(nhc.Spec.Selector.MatchExpressions) == 0 && len(nhc.Spec.Selector.MatchLabels) == 0 {
 		return fmt.Errorf(missingSelectorError)
@@ -189,20 +393,450 @@ func (nhc *NodeHealthCheck) validateEscalatingRemediationsTimeout() error {
 	return nil
 }
 
-func (nhc *NodeHealthCheck) isRestrictedFieldUpdated(old *NodeHealthCheck) (bool, string) {
-	// modifying these fields can cause dangling remediations
-	if !reflect.DeepEqual(nhc.Spec.Selector, old.Spec.Selector) {
-		return true, "selector"
+func (nhc *NodeHealthCheck) validateUnhealthyExpression() error {
+	if nhc.Spec.UnhealthyExpression == "" {
+		return nil
+	}
+	if _, err := expression.Compile(nhc.Spec.UnhealthyExpression); err != nil {
+		return fmt.Errorf("%s: %v", invalidExpressionError, err)
+	}
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validateUnhealthyPods() error {
+	for i, up := range nhc.Spec.UnhealthyPods {
+		if up.Selector == nil && up.DaemonSet == "" {
+			return fmt.Errorf("%s: unhealthyPods[%d] needs a selector or a daemonSet", invalidUnhealthyPodError, i)
+		}
+		if up.Selector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(up.Selector); err != nil {
+			return fmt.Errorf("%s: unhealthyPods[%d] has an invalid selector: %v", invalidUnhealthyPodError, i, err)
+		}
+	}
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validatePrometheusQuery() error {
+	pq := nhc.Spec.PrometheusQuery
+	if pq == nil {
+		return nil
+	}
+	if u, err := url.Parse(pq.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s: url needs to be a http or https URL: %s", invalidPrometheusQueryError, pq.URL)
 	}
-	if !reflect.DeepEqual(nhc.Spec.RemediationTemplate, old.Spec.RemediationTemplate) {
-		return true, "remediation template"
+	if _, err := strconv.ParseFloat(pq.Threshold, 64); err != nil {
+		return fmt.Errorf("%s: threshold needs to be a decimal number: %v", invalidPrometheusQueryError, err)
 	}
-	if !reflect.DeepEqual(nhc.Spec.EscalatingRemediations, old.Spec.EscalatingRemediations) {
-		return true, "escalating remediations"
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validateFlappingDetection() error {
+	fd := nhc.Spec.FlappingDetection
+	if fd != nil && fd.Action == FlappingActionEscalate && len(nhc.Spec.EscalatingRemediations) == 0 {
+		return fmt.Errorf(flappingEscalationError)
+	}
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validateTopology() error {
+	if nhc.Spec.Topology == nil {
+		return nil
+	}
+	if errs := validation.IsQualifiedName(nhc.Spec.Topology.Key); len(errs) > 0 {
+		return fmt.Errorf("%s: %s", invalidTopologyKeyError, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (nhc *NodeHealthCheck) validateStormDetection() error {
+	// Using Minimum kubebuilder marker for IntOrStr does not work (yet)
+	if sd := nhc.Spec.StormDetection; sd != nil && sd.MaxNewUnhealthyNodes.Type == intstr.Int && sd.MaxNewUnhealthyNodes.IntVal < 0 {
+		return fmt.Errorf("%s: %v", stormDetectionError, sd.MaxNewUnhealthyNodes)
+	}
+	return nil
+}
+
+// validateTemplates checks that all referenced remediation templates are valid.
+// Templates which don't exist (yet) result in a warning only, because they might be created later.
+func (nhc *NodeHealthCheck) validateTemplates(ctx context.Context, c client.Client) (warnings []string, err error) {
+	var templateRefs []corev1.ObjectReference
+	if nhc.Spec.RemediationTemplate != nil {
+		templateRefs = append(templateRefs, *nhc.Spec.RemediationTemplate)
+	}
+	for _, escRem := range nhc.Spec.EscalatingRemediations {
+		templateRefs = append(templateRefs, escRem.RemediationTemplate)
+	}
+
+	var errs []error
+	for _, templateRef := range templateRefs {
+		warning, err := validateTemplate(ctx, c, templateRef)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return warnings, errors.NewAggregate(errs)
+}
+
+func validateTemplate(ctx context.Context, c client.Client, templateRef corev1.ObjectReference) (warning string, err error) {
+	template := &unstructured.Unstructured{}
+	template.SetGroupVersionKind(templateRef.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKey{Namespace: templateRef.Namespace, Name: templateRef.Name}, template); err != nil {
+		if meta.IsNoMatchError(err) {
+			return "", fmt.Errorf("%s: %s", templateKindNotServedError, templateRef.GroupVersionKind().String())
+		}
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("%s: %s %s/%s", templateNotFoundWarning, templateRef.Kind, templateRef.Namespace, templateRef.Name), nil
+		}
+		// don't block admission because of unexpected errors, the controller will check the template again
+		nodehealthchecklog.Error(err, "failed to get remediation template", "template", templateRef)
+		return fmt.Sprintf("failed to verify RemediationTemplate %s %s/%s: %v", templateRef.Kind, templateRef.Namespace, templateRef.Name, err), nil
+	}
+
+	if _, found, err := unstructured.NestedMap(template.Object, "spec", "template"); !found || err != nil {
+		return "", fmt.Errorf("%s: %s %s/%s doesn't have a spec.template field", invalidTemplateError, templateRef.Kind, templateRef.Namespace, templateRef.Name)
+	}
+
+	// Metal3 remediation needs the node's machine as owner ref,
+	// and owners need to be in the same namespace as their dependent.
+	if template.GetKind() == metal3RemediationTemplateKind && template.GetNamespace() != machineAPINamespace {
+		return "", fmt.Errorf("%s: %s must be in the %s namespace, but it is in namespace %s", invalidTemplateError, metal3RemediationTemplateKind, machineAPINamespace, template.GetNamespace())
+	}
+
+	return "", nil
+}
+
+// validateSelectorOverlap checks if the selector overlaps with the selector of other NodeHealthChecks.
+// Nodes which are selected by multiple NodeHealthChecks result in an error, because the NodeHealthChecks would compete
+// for remediating them. Selectors which don't select common nodes yet, but might do so in future, result in a warning.
+func (nhc *NodeHealthCheck) validateSelectorOverlap(ctx context.Context, c client.Client) (warnings []string, err error) {
+	selector, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector)
+	if err != nil {
+		// already covered by validateSelector
+		return nil, nil
+	}
+
+	nhcList := &NodeHealthCheckList{}
+	if err := c.List(ctx, nhcList); err != nil {
+		// don't block admission because of unexpected errors, the controller will check for overlaps again
+		nodehealthchecklog.Error(err, "failed to list NodeHealthChecks")
+		return []string{fmt.Sprintf("failed to verify selector overlap: %v", err)}, nil
+	}
+	nodeList := &corev1.NodeList{}
+	if err := c.List(ctx, nodeList); err != nil {
+		nodehealthchecklog.Error(err, "failed to list nodes")
+		return []string{fmt.Sprintf("failed to verify selector overlap: %v", err)}, nil
+	}
+
+	var errs []error
+	for _, other := range nhcList.Items {
+		if other.Name == nhc.Name {
+			continue
+		}
+		otherSelector, err := metav1.LabelSelectorAsSelector(&other.Spec.Selector)
+		if err != nil {
+			continue
+		}
+
+		var sharedNodes []string
+		for _, node := range nodeList.Items {
+			nodeLabels := labels.Set(node.GetLabels())
+			if selector.Matches(nodeLabels) && otherSelector.Matches(nodeLabels) {
+				sharedNodes = append(sharedNodes, node.Name)
+			}
+		}
+		if len(sharedNodes) > 0 {
+			errs = append(errs, fmt.Errorf("%s: NodeHealthCheck %s, shared nodes: %s", overlappingSelectorError, other.Name, strings.Join(sharedNodes, ", ")))
+		} else if selectorsMightOverlap(selector, otherSelector) {
+			warnings = append(warnings, fmt.Sprintf("%s: NodeHealthCheck %s", overlappingSelectorWarning, other.Name))
+		}
+	}
+	return warnings, errors.NewAggregate(errs)
+}
+
+// selectorsMightOverlap returns false if the given selectors can never match the same set of labels,
+// because they have contradicting requirements on the same label key.
+func selectorsMightOverlap(s1, s2 labels.Selector) bool {
+	reqs1, _ := s1.Requirements()
+	reqs2, _ := s2.Requirements()
+	for _, r1 := range reqs1 {
+		for _, r2 := range reqs2 {
+			if r1.Key() == r2.Key() && (requirementsContradict(r1, r2) || requirementsContradict(r2, r1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// requirementsContradict checks if there is no label value which satisfies both requirements on the same key.
+// Operators which aren't handled here are considered to be satisfiable together.
+func requirementsContradict(r1, r2 labels.Requirement) bool {
+	switch r1.Operator() {
+	case selection.Exists:
+		return r2.Operator() == selection.DoesNotExist
+	case selection.In, selection.Equals, selection.DoubleEquals:
+		switch r2.Operator() {
+		case selection.DoesNotExist:
+			return true
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			return !r1.Values().HasAny(r2.Values().UnsortedList()...)
+		case selection.NotIn, selection.NotEquals:
+			return r2.Values().IsSuperset(r1.Values())
+		}
+	}
+	return false
+}
+
+func (nhc *NodeHealthCheck) isTemplateUpdated(old *NodeHealthCheck) bool {
+	return !reflect.DeepEqual(nhc.Spec.RemediationTemplate, old.Spec.RemediationTemplate) ||
+		!reflect.DeepEqual(nhc.Spec.EscalatingRemediations, old.Spec.EscalatingRemediations)
+}
+
+// validateUpdateDuringRemediation rejects updates which would orphan remediation CRs of nodes under remediation.
+// Updates which are safe, like raising timeouts, or appending escalating remediations after the current one,
+// are allowed. Selector updates are validated by validateSelectorUpdateDuringRemediation, because they depend on
+// the labels of the remediating nodes.
+func (nhc *NodeHealthCheck) validateUpdateDuringRemediation(old *NodeHealthCheck) error {
+	remediatingNodes := nhc.getRemediatingNodes()
+	var errs []error
+	if nodes := nhc.getNodesAffectedByTemplateUpdate(old, remediatingNodes); len(nodes) > 0 {
+		errs = append(errs, newOngoingRemediationError("remediation template", nodes))
+	}
+	if nodes := nhc.getNodesAffectedByEscalatingRemediationsUpdate(old, remediatingNodes); len(nodes) > 0 {
+		errs = append(errs, newOngoingRemediationError("escalating remediations", nodes))
+	}
+	return errors.NewAggregate(errs)
+}
+
+func newOngoingRemediationError(field string, nodes []string) error {
+	sort.Strings(nodes)
+	return fmt.Errorf("%s update %s, affected nodes: %s", field, OngoingRemediationError, strings.Join(nodes, ", "))
+}
+
+// getRemediatingNodes returns the remediations of all nodes under remediation, keyed by node name.
+// Nodes which are only tracked in the deprecated InFlightRemediations field have nil remediations,
+// which means that their remediation CRs are unknown.
+// Nodes whose remediation is deferred by the kubelet probe aren't remediating.
+func (nhc *NodeHealthCheck) getRemediatingNodes() map[string][]*Remediation {
+	remediatingNodes := make(map[string][]*Remediation)
+	for nodeName := range nhc.Status.InFlightRemediations {
+		remediatingNodes[nodeName] = nil
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode == nil || len(unhealthyNode.Remediations) == 0 {
+			continue
+		}
+		remediatingNodes[unhealthyNode.Name] = append([]*Remediation{}, unhealthyNode.Remediations...)
+	}
+	return remediatingNodes
+}
+
+// validateSelectorUpdateDuringRemediation rejects selector updates which would deselect nodes under remediation,
+// and so orphan their remediation CRs. Narrowing the selector is allowed, as long as all remediating nodes stay
+// selected.
+func (nhc *NodeHealthCheck) validateSelectorUpdateDuringRemediation(ctx context.Context, c client.Client, old *NodeHealthCheck) error {
+	if !nhc.isRemediating() {
+		return nil
+	}
+	if nodes := nhc.getNodesAffectedBySelectorUpdate(ctx, c, old, nhc.getRemediatingNodes()); len(nodes) > 0 {
+		return newOngoingRemediationError("selector", nodes)
+	}
+	return nil
+}
+
+// getNodesAffectedBySelectorUpdate returns the remediating nodes which aren't selected by the new selector anymore.
+// Deleted nodes aren't affected, their remediations are finished regardless of the selector. When the nodes can't be
+// listed, all remediating nodes are returned, unless the new selector selects all nodes of the old one.
+func (nhc *NodeHealthCheck) getNodesAffectedBySelectorUpdate(ctx context.Context, c client.Client, old *NodeHealthCheck, remediatingNodes map[string][]*Remediation) []string {
+	if reflect.DeepEqual(nhc.Spec.Selector, old.Spec.Selector) {
+		return nil
+	}
+	newSelector, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector)
+	if err != nil {
+		// already covered by validateSelector
+		return nil
+	}
+	oldSelector, err := metav1.LabelSelectorAsSelector(&old.Spec.Selector)
+	if err == nil && selectorIncludes(newSelector, oldSelector) {
+		return nil
+	}
+
+	nodes := make([]string, 0, len(remediatingNodes))
+	nodeList := &corev1.NodeList{}
+	if err := c.List(ctx, nodeList); err != nil {
+		nodehealthchecklog.Error(err, "failed to list nodes")
+		for nodeName := range remediatingNodes {
+			nodes = append(nodes, nodeName)
+		}
+		return nodes
+	}
+	for _, node := range nodeList.Items {
+		if _, isRemediating := remediatingNodes[node.Name]; isRemediating && !newSelector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	return nodes
+}
+
+// getNodesAffectedByTemplateUpdate returns the remediating nodes with remediation CRs which wouldn't be found anymore
+// with the updated remediation template
+func (nhc *NodeHealthCheck) getNodesAffectedByTemplateUpdate(old *NodeHealthCheck, remediatingNodes map[string][]*Remediation) []string {
+	if reflect.DeepEqual(nhc.Spec.RemediationTemplate, old.Spec.RemediationTemplate) {
+		return nil
+	}
+	return nhc.getNodesWithOrphanedRemediations(remediatingNodes)
+}
+
+// getNodesAffectedByEscalatingRemediationsUpdate returns the remediating nodes with remediation CRs which wouldn't be
+// found anymore, or for which the already used or current escalating remediations were modified.
+func (nhc *NodeHealthCheck) getNodesAffectedByEscalatingRemediationsUpdate(old *NodeHealthCheck, remediatingNodes map[string][]*Remediation) []string {
+	if reflect.DeepEqual(nhc.Spec.EscalatingRemediations, old.Spec.EscalatingRemediations) {
+		return nil
+	}
+
+	affected := sets.NewString(nhc.getNodesWithOrphanedRemediations(remediatingNodes)...)
+
+	oldSteps := sortedEscalatingRemediations(old.Spec.EscalatingRemediations)
+	newSteps := sortedEscalatingRemediations(nhc.Spec.EscalatingRemediations)
+	for nodeName, remediations := range remediatingNodes {
+		current := getCurrentEscalatingRemediationIndex(oldSteps, remediations)
+		// everything up to the current remediation needs to be unchanged, except for raised timeouts
+		for i := 0; i <= current; i++ {
+			if i >= len(newSteps) ||
+				newSteps[i].Order != oldSteps[i].Order ||
+				!reflect.DeepEqual(newSteps[i].RemediationTemplate, oldSteps[i].RemediationTemplate) ||
+				newSteps[i].Timeout.Duration < oldSteps[i].Timeout.Duration {
+				affected.Insert(nodeName)
+				break
+			}
+		}
+	}
+	return affected.UnsortedList()
+}
+
+// getNodesWithOrphanedRemediations returns the remediating nodes with remediation CRs whose kind isn't referenced
+// by any remediation template anymore. Nodes with unknown remediations are always returned.
+func (nhc *NodeHealthCheck) getNodesWithOrphanedRemediations(remediatingNodes map[string][]*Remediation) []string {
+	var templateRefs []corev1.ObjectReference
+	if nhc.Spec.RemediationTemplate != nil {
+		templateRefs = append(templateRefs, *nhc.Spec.RemediationTemplate)
+	}
+	for _, escRem := range nhc.Spec.EscalatingRemediations {
+		templateRefs = append(templateRefs, escRem.RemediationTemplate)
+	}
+	remediationGVKs := make(map[schema.GroupVersionKind]struct{}, len(templateRefs))
+	for _, templateRef := range templateRefs {
+		remediationGVKs[getRemediationGVK(templateRef)] = struct{}{}
+	}
+
+	var nodes []string
+	for nodeName, remediations := range remediatingNodes {
+		if remediations == nil {
+			nodes = append(nodes, nodeName)
+			continue
+		}
+		for _, remediation := range remediations {
+			if _, exists := remediationGVKs[remediation.Resource.GroupVersionKind()]; !exists {
+				nodes = append(nodes, nodeName)
+				break
+			}
+		}
+	}
+	return nodes
+}
+
+// getCurrentEscalatingRemediationIndex returns the index of the first escalating remediation which didn't time out
+// yet, in the same way as the controller does. For unknown remediations the last index is returned.
+func getCurrentEscalatingRemediationIndex(steps []EscalatingRemediation, remediations []*Remediation) int {
+	if remediations == nil {
+		return len(steps) - 1
+	}
+	for i, step := range steps {
+		timedOut := false
+		for _, remediation := range remediations {
+			if remediation.Resource.GroupVersionKind() == getRemediationGVK(step.RemediationTemplate) && remediation.TimedOut != nil {
+				timedOut = true
+				break
+			}
+		}
+		if !timedOut {
+			return i
+		}
+	}
+	return len(steps) - 1
+}
+
+func sortedEscalatingRemediations(escRems []EscalatingRemediation) []EscalatingRemediation {
+	sorted := append([]EscalatingRemediation{}, escRems...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+	return sorted
+}
+
+// getRemediationGVK returns the GVK of remediation CRs created from the given template
+func getRemediationGVK(templateRef corev1.ObjectReference) schema.GroupVersionKind {
+	gvk := templateRef.GroupVersionKind()
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, templateSuffix)
+	return gvk
+}
+
+// selectorIncludes returns true if the wide selector is known to select all label sets which are selected by the
+// narrow selector, which is the case when all of its requirements are implied by the narrow selector's requirements.
+func selectorIncludes(wide, narrow labels.Selector) bool {
+	wideReqs, _ := wide.Requirements()
+	narrowReqs, _ := narrow.Requirements()
+	for _, wideReq := range wideReqs {
+		implied := false
+		for _, narrowReq := range narrowReqs {
+			if narrowReq.Key() == wideReq.Key() && requirementImplies(narrowReq, wideReq) {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			return false
+		}
+	}
+	return true
+}
+
+// requirementImplies checks if every label value which satisfies r1 also satisfies r2, for requirements
+// on the same key. Operators which aren't handled here are considered to not imply each other.
+func requirementImplies(r1, r2 labels.Requirement) bool {
+	switch r2.Operator() {
+	case selection.Exists:
+		switch r1.Operator() {
+		case selection.Exists, selection.In, selection.Equals, selection.DoubleEquals:
+			return true
+		}
+	case selection.DoesNotExist:
+		return r1.Operator() == selection.DoesNotExist
+	case selection.In, selection.Equals, selection.DoubleEquals:
+		switch r1.Operator() {
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			return r2.Values().IsSuperset(r1.Values())
+		}
+	case selection.NotIn, selection.NotEquals:
+		switch r1.Operator() {
+		case selection.DoesNotExist:
+			return true
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			return !r1.Values().HasAny(r2.Values().UnsortedList()...)
+		case selection.NotIn, selection.NotEquals:
+			return r1.Values().IsSuperset(r2.Values())
+		}
+	case selection.GreaterThan, selection.LessThan:
+		return r1.Operator() == r2.Operator() && reflect.DeepEqual(r1.Values(), r2.Values())
 	}
-	return false, ""
+	return false
 }
 
 func (nhc *NodeHealthCheck) isRemediating() bool {
-	return len(nhc.Status.InFlightRemediations) > 0 || len(nhc.Status.UnhealthyNodes) > 0
+	return len(nhc.getRemediatingNodes()) > 0
 }
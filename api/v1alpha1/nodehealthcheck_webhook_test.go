@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"os"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -70,6 +71,160 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 			})
 		})
 
+		Context("with negative maxUnhealthy", func() {
+			BeforeEach(func() {
+				mu := intstr.FromInt(-1)
+				nhc.Spec.MaxUnhealthy = &mu
+			})
+
+			It("should be denied", func() {
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(maxUnhealthyError)))
+			})
+		})
+
+		Context("with valid unhealthy range", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyRange = "[1-5]"
+			})
+
+			It("should be allowed", func() {
+				Expect(nhc.validate()).To(Succeed())
+			})
+		})
+
+		Context("with unhealthy range min greater than max", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyRange = "[5-1]"
+			})
+
+			It("should be denied", func() {
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(unhealthyRangeError)))
+			})
+		})
+
+		Context("with valid unhealthy expression", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyExpression = `conditions.exists(c, c.type == "Ready" && c.reason == "KubeletNotReady")`
+			})
+
+			It("should be allowed", func() {
+				Expect(nhc.validate()).To(Succeed())
+			})
+		})
+
+		Context("with invalid unhealthy expression", func() {
+			BeforeEach(func() {
+				nhc.Spec.UnhealthyExpression = `conditions.exists(c, c.type == `
+			})
+
+			It("should be denied", func() {
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidExpressionError)))
+			})
+		})
+
+		Context("with prometheus query", func() {
+			BeforeEach(func() {
+				nhc.Spec.PrometheusQuery = &PrometheusQuery{
+					URL:       "https://thanos-querier.openshift-monitoring.svc:9091",
+					Query:     "node_filesystem_avail_bytes",
+					Threshold: "1000",
+					Duration:  metav1.Duration{Duration: time.Minute},
+				}
+			})
+
+			It("should be allowed with valid url and threshold", func() {
+				Expect(nhc.validate()).To(Succeed())
+			})
+
+			It("should be denied with invalid url", func() {
+				nhc.Spec.PrometheusQuery.URL = "thanos-querier:9091"
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidPrometheusQueryError)))
+			})
+
+			It("should be denied with invalid threshold", func() {
+				nhc.Spec.PrometheusQuery.Threshold = "1k"
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidPrometheusQueryError)))
+			})
+		})
+
+		Context("with topology", func() {
+			It("should be allowed with a valid label key", func() {
+				nhc.Spec.Topology = &Topology{Key: "topology.kubernetes.io/zone"}
+				Expect(nhc.validate()).To(Succeed())
+			})
+
+			It("should be denied with an invalid label key", func() {
+				nhc.Spec.Topology = &Topology{Key: "zone/rack/row"}
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidTopologyKeyError)))
+			})
+		})
+
+		Context("with storm detection", func() {
+			BeforeEach(func() {
+				nhc.Spec.StormDetection = &StormDetection{
+					MaxNewUnhealthyNodes: intstr.FromString("30%"),
+					Window:               metav1.Duration{Duration: 5 * time.Minute},
+					StabilizationPeriod:  metav1.Duration{Duration: 10 * time.Minute},
+				}
+			})
+
+			It("should be allowed with a percentage", func() {
+				Expect(nhc.validate()).To(Succeed())
+			})
+
+			It("should be denied with a negative number of nodes", func() {
+				nhc.Spec.StormDetection.MaxNewUnhealthyNodes = intstr.FromInt(-1)
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(stormDetectionError)))
+			})
+		})
+
+		Context("with flapping detection", func() {
+			BeforeEach(func() {
+				nhc.Spec.FlappingDetection = &FlappingDetection{
+					MaxTransitions: 3,
+					Window:         metav1.Duration{Duration: time.Hour},
+					Action:         FlappingActionEscalate,
+				}
+			})
+
+			It("should be allowed to escalate with escalating remediations", func() {
+				setEscalatingRemediations(nhc)
+				Expect(nhc.validate()).To(Succeed())
+			})
+
+			It("should be denied to escalate without escalating remediations", func() {
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(flappingEscalationError)))
+			})
+
+			It("should be allowed to stop remediation without escalating remediations", func() {
+				nhc.Spec.FlappingDetection.Action = FlappingActionStopRemediation
+				Expect(nhc.validate()).To(Succeed())
+			})
+		})
+
+		Context("with unhealthy pods", func() {
+			It("should be allowed with a daemonset", func() {
+				nhc.Spec.UnhealthyPods = []UnhealthyPod{{Namespace: "test", DaemonSet: "test", Duration: metav1.Duration{Duration: time.Minute}}}
+				Expect(nhc.validate()).To(Succeed())
+			})
+
+			It("should be denied without selector and daemonset", func() {
+				nhc.Spec.UnhealthyPods = []UnhealthyPod{{Namespace: "test", Duration: metav1.Duration{Duration: time.Minute}}}
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidUnhealthyPodError)))
+			})
+
+			It("should be denied with an invalid selector", func() {
+				nhc.Spec.UnhealthyPods = []UnhealthyPod{{
+					Namespace: "test",
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "foo"}},
+					},
+					Duration: metav1.Duration{Duration: time.Minute},
+				}}
+				Expect(nhc.validate()).To(MatchError(ContainSubstring(invalidUnhealthyPodError)))
+			})
+		})
+
 		Context("with invalid selector", func() {
 			BeforeEach(func() {
 				selector := metav1.LabelSelector{
@@ -180,21 +335,6 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 			}
 		})
 
-		Context("updating selector", func() {
-			BeforeEach(func() {
-				nhcNew = nhcOld.DeepCopy()
-				nhcNew.Spec.Selector.MatchExpressions[0].Key = "node-role.kubernetes.io/infra"
-			})
-			It("should be denied", func() {
-				Expect(nhcNew.ValidateUpdate(nhcOld)).To(MatchError(
-					And(
-						ContainSubstring(OngoingRemediationError),
-						ContainSubstring("selector"),
-					),
-				))
-			})
-		})
-
 		Context("updating remediation template", func() {
 			BeforeEach(func() {
 				nhcNew = nhcOld.DeepCopy()
@@ -225,6 +365,327 @@ var _ = Describe("NodeHealthCheck Validation", func() {
 				))
 			})
 		})
+
+		Context("with known remediations", func() {
+
+			newRemediation := func(kind string, timedOut bool) *Remediation {
+				rem := &Remediation{
+					Resource: v1.ObjectReference{
+						Kind:       kind,
+						Namespace:  "dummy",
+						Name:       "node1",
+						APIVersion: "test.medik8s.io/v1alpha1",
+					},
+					Started: metav1.Now(),
+				}
+				if timedOut {
+					now := metav1.Now()
+					rem.TimedOut = &now
+				}
+				return rem
+			}
+
+			BeforeEach(func() {
+				nhcOld.Spec.RemediationTemplate = &v1.ObjectReference{
+					Kind:       "R1Template",
+					Namespace:  "dummy",
+					Name:       "r1",
+					APIVersion: "test.medik8s.io/v1alpha1",
+				}
+				nhcOld.Status = NodeHealthCheckStatus{
+					UnhealthyNodes: []*UnhealthyNode{
+						{
+							Name:         "node1",
+							Remediations: []*Remediation{newRemediation("R1", false)},
+						},
+					},
+				}
+				nhcNew = nhcOld.DeepCopy()
+			})
+
+			Context("widening selector", func() {
+				It("should be allowed when removing a requirement", func() {
+					nhcNew.Spec.Selector.MatchExpressions = nil
+					nhcNew.Spec.Selector.MatchLabels = map[string]string{"foo": "bar"}
+					nhcOld.Spec.Selector.MatchLabels = map[string]string{"foo": "bar"}
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(Succeed())
+				})
+				It("should be allowed when adding values", func() {
+					nhcOld.Spec.Selector.MatchExpressions = append(nhcOld.Spec.Selector.MatchExpressions, metav1.LabelSelectorRequirement{
+						Key:      "zone",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"a"},
+					})
+					nhcNew = nhcOld.DeepCopy()
+					nhcNew.Spec.Selector.MatchExpressions[1].Values = []string{"a", "b"}
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(Succeed())
+				})
+			})
+
+			Context("updating remediation template", func() {
+				It("should be allowed with same kind", func() {
+					nhcNew.Spec.RemediationTemplate.Name = "newName"
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(Succeed())
+				})
+				It("should be denied with another kind", func() {
+					nhcNew.Spec.RemediationTemplate.Kind = "R2Template"
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(MatchError(
+						And(
+							ContainSubstring(OngoingRemediationError),
+							ContainSubstring("remediation template"),
+							ContainSubstring("node1"),
+						),
+					))
+				})
+			})
+
+			Context("updating escalating remediations", func() {
+				BeforeEach(func() {
+					setEscalatingRemediations(nhcOld)
+					for i := range nhcOld.Spec.EscalatingRemediations {
+						nhcOld.Spec.EscalatingRemediations[i].RemediationTemplate.APIVersion = "test.medik8s.io/v1alpha1"
+					}
+					// R1 timed out, R2 is the current remediation
+					nhcOld.Status.UnhealthyNodes[0].Remediations = []*Remediation{
+						newRemediation("R1", true),
+						newRemediation("R2", false),
+					}
+					nhcNew = nhcOld.DeepCopy()
+				})
+
+				It("should be allowed to raise timeouts", func() {
+					nhcNew.Spec.EscalatingRemediations[0].Timeout = metav1.Duration{Duration: 5 * time.Minute}
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(Succeed())
+				})
+
+				It("should be allowed to append remediations after the current one", func() {
+					nhcNew.Spec.EscalatingRemediations = append(nhcNew.Spec.EscalatingRemediations, EscalatingRemediation{
+						RemediationTemplate: v1.ObjectReference{
+							Kind:       "R4Template",
+							Namespace:  "dummy",
+							Name:       "r4",
+							APIVersion: "test.medik8s.io/v1alpha1",
+						},
+						Order:   25,
+						Timeout: metav1.Duration{Duration: 2 * time.Minute},
+					})
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(Succeed())
+				})
+
+				It("should be denied to lower the timeout of the current remediation", func() {
+					// order 20
+					nhcNew.Spec.EscalatingRemediations[0].Timeout = metav1.Duration{Duration: 1 * time.Minute}
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(MatchError(
+						And(
+							ContainSubstring(OngoingRemediationError),
+							ContainSubstring("escalating remediations"),
+							ContainSubstring("node1"),
+						),
+					))
+				})
+
+				It("should be denied to insert remediations before the current one", func() {
+					nhcNew.Spec.EscalatingRemediations = append(nhcNew.Spec.EscalatingRemediations, EscalatingRemediation{
+						RemediationTemplate: v1.ObjectReference{
+							Kind:       "R4Template",
+							Namespace:  "dummy",
+							Name:       "r4",
+							APIVersion: "test.medik8s.io/v1alpha1",
+						},
+						Order:   15,
+						Timeout: metav1.Duration{Duration: 2 * time.Minute},
+					})
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(MatchError(ContainSubstring("node1")))
+				})
+
+				It("should be denied to remove a used remediation", func() {
+					// order 10
+					nhcNew.Spec.EscalatingRemediations = nhcNew.Spec.EscalatingRemediations[:2]
+					Expect(nhcNew.ValidateUpdate(nhcOld)).To(MatchError(ContainSubstring("node1")))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("NodeHealthCheck Template Validation", func() {
+
+	var nhc *NodeHealthCheck
+
+	BeforeEach(func() {
+		nhc = &NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: NodeHealthCheckSpec{
+				RemediationTemplate: &v1.ObjectReference{
+					Kind:       "InfrastructureRemediationTemplate",
+					Namespace:  "default",
+					Name:       "template",
+					APIVersion: "test.medik8s.io/v1alpha1",
+				},
+			},
+		}
+	})
+
+	Context("with valid template", func() {
+		It("should be allowed without warnings", func() {
+			warnings, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("with not existing template", func() {
+		BeforeEach(func() {
+			nhc.Spec.RemediationTemplate.Name = "doesNotExist"
+		})
+		It("should be allowed with warning", func() {
+			warnings, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring(templateNotFoundWarning)))
+		})
+	})
+
+	Context("with not served template kind", func() {
+		BeforeEach(func() {
+			nhc.Spec.RemediationTemplate.Kind = "DummyTemplate"
+		})
+		It("should be denied", func() {
+			_, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).To(MatchError(ContainSubstring(templateKindNotServedError)))
+		})
+	})
+
+	Context("with template without spec.template", func() {
+		BeforeEach(func() {
+			nhc.Spec.RemediationTemplate.Name = "broken"
+		})
+		It("should be denied", func() {
+			_, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).To(MatchError(ContainSubstring(invalidTemplateError)))
+		})
+	})
+
+	Context("with Metal3 template", func() {
+		BeforeEach(func() {
+			nhc.Spec.RemediationTemplate.Kind = metal3RemediationTemplateKind
+		})
+
+		It("should be allowed in the machine API namespace", func() {
+			nhc.Spec.RemediationTemplate.Namespace = machineAPINamespace
+			nhc.Spec.RemediationTemplate.Name = "ok"
+			warnings, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should be denied in other namespaces", func() {
+			nhc.Spec.RemediationTemplate.Name = "nok"
+			_, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(err).To(MatchError(ContainSubstring(machineAPINamespace)))
+		})
+	})
+
+	Context("with escalating remediations", func() {
+		It("should check all templates", func() {
+			valid := *nhc.Spec.RemediationTemplate
+			missing := valid
+			missing.Name = "doesNotExist"
+			broken := valid
+			broken.Name = "broken"
+			nhc.Spec.RemediationTemplate = nil
+			nhc.Spec.EscalatingRemediations = []EscalatingRemediation{
+				{RemediationTemplate: valid, Order: 1, Timeout: metav1.Duration{Duration: time.Minute}},
+				{RemediationTemplate: missing, Order: 2, Timeout: metav1.Duration{Duration: time.Minute}},
+				{RemediationTemplate: broken, Order: 3, Timeout: metav1.Duration{Duration: time.Minute}},
+			}
+			warnings, err := nhc.validateTemplates(ctx, k8sClient)
+			Expect(warnings).To(ConsistOf(ContainSubstring("doesNotExist")))
+			Expect(err).To(MatchError(ContainSubstring("broken")))
+		})
+	})
+})
+
+var _ = Describe("NodeHealthCheck Defaulting", func() {
+
+	var nhc *NodeHealthCheck
+
+	BeforeEach(func() {
+		nhc = &NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: NodeHealthCheckSpec{
+				RemediationTemplate: &v1.ObjectReference{
+					Kind:       "R",
+					Name:       "r",
+					APIVersion: "r",
+				},
+			},
+		}
+	})
+
+	Context("with deployment namespace set", func() {
+		BeforeEach(func() {
+			os.Setenv(deploymentNamespaceEnvVar, "operator-ns")
+			DeferCleanup(os.Unsetenv, deploymentNamespaceEnvVar)
+		})
+
+		It("should set the namespace of the remediation template", func() {
+			nhc.Default()
+			Expect(nhc.Spec.RemediationTemplate.Namespace).To(Equal("operator-ns"))
+		})
+
+		It("should set the namespaces of escalating remediation templates", func() {
+			setEscalatingRemediations(nhc)
+			nhc.Spec.EscalatingRemediations[0].RemediationTemplate.Namespace = ""
+			nhc.Default()
+			for _, rem := range nhc.Spec.EscalatingRemediations {
+				if rem.RemediationTemplate.Name == "r2" {
+					Expect(rem.RemediationTemplate.Namespace).To(Equal("operator-ns"))
+				} else {
+					Expect(rem.RemediationTemplate.Namespace).To(Equal("dummy"))
+				}
+			}
+		})
+
+		It("should not overwrite an existing namespace", func() {
+			nhc.Spec.RemediationTemplate.Namespace = "dummy"
+			nhc.Default()
+			Expect(nhc.Spec.RemediationTemplate.Namespace).To(Equal("dummy"))
+		})
+	})
+
+	Context("with minHealthy", func() {
+		It("should set the default if empty", func() {
+			nhc.Default()
+			Expect(*nhc.Spec.MinHealthy).To(Equal(intstr.FromString("51%")))
+		})
+
+		It("should convert numeric strings to integers", func() {
+			mh := intstr.FromString("3")
+			nhc.Spec.MinHealthy = &mh
+			nhc.Default()
+			Expect(*nhc.Spec.MinHealthy).To(Equal(intstr.FromInt(3)))
+		})
+
+		It("should keep percentages", func() {
+			mh := intstr.FromString("30%")
+			nhc.Spec.MinHealthy = &mh
+			nhc.Default()
+			Expect(*nhc.Spec.MinHealthy).To(Equal(intstr.FromString("30%")))
+		})
+	})
+
+	Context("with escalating remediations", func() {
+		It("should sort them by order", func() {
+			setEscalatingRemediations(nhc)
+			nhc.Default()
+			Expect(nhc.Spec.EscalatingRemediations[0].Order).To(Equal(10))
+			Expect(nhc.Spec.EscalatingRemediations[1].Order).To(Equal(20))
+			Expect(nhc.Spec.EscalatingRemediations[2].Order).To(Equal(30))
+		})
 	})
 })
 
@@ -263,3 +724,230 @@ func setEscalatingRemediations(nhc *NodeHealthCheck) {
 		},
 	}
 }
+
+var _ = Describe("NodeHealthCheck Selector Overlap Validation", func() {
+
+	var nhc, otherNHC *NodeHealthCheck
+	var node *v1.Node
+
+	newNHC := func(name string, selector metav1.LabelSelector) *NodeHealthCheck {
+		mh := intstr.FromString("51%")
+		return &NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: NodeHealthCheckSpec{
+				MinHealthy: &mh,
+				Selector:   selector,
+				RemediationTemplate: &v1.ObjectReference{
+					Kind:       "InfrastructureRemediationTemplate",
+					Namespace:  "default",
+					Name:       "template",
+					APIVersion: "test.medik8s.io/v1alpha1",
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		node = &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "overlap-node",
+				Labels: map[string]string{
+					"role": "worker",
+					"zone": "a",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+		otherNHC = newNHC("other", metav1.LabelSelector{
+			MatchLabels: map[string]string{"role": "worker"},
+		})
+		Expect(k8sClient.Create(ctx, otherNHC)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, otherNHC)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, node)).To(Succeed())
+		})
+	})
+
+	Context("with selector matching the same nodes", func() {
+		BeforeEach(func() {
+			nhc = newNHC("test", metav1.LabelSelector{
+				MatchLabels: map[string]string{"zone": "a"},
+			})
+		})
+		It("should be denied with the shared nodes", func() {
+			_, err := nhc.validateSelectorOverlap(ctx, k8sClient)
+			Expect(err).To(MatchError(ContainSubstring(overlappingSelectorError)))
+			Expect(err).To(MatchError(ContainSubstring("other")))
+			Expect(err).To(MatchError(ContainSubstring("overlap-node")))
+		})
+	})
+
+	Context("with selector which might match the same nodes in future", func() {
+		BeforeEach(func() {
+			nhc = newNHC("test", metav1.LabelSelector{
+				MatchLabels: map[string]string{"zone": "b"},
+			})
+		})
+		It("should be allowed with warning", func() {
+			warnings, err := nhc.validateSelectorOverlap(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring(overlappingSelectorWarning)))
+		})
+	})
+
+	Context("with disjoint selector", func() {
+		BeforeEach(func() {
+			nhc = newNHC("test", metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "master"},
+			})
+		})
+		It("should be allowed without warnings", func() {
+			warnings, err := nhc.validateSelectorOverlap(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("when updating the other NHC itself", func() {
+		It("should not compare it with itself", func() {
+			warnings, err := otherNHC.validateSelectorOverlap(ctx, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("NodeHealthCheck Selector Update Validation", func() {
+
+	var nhcOld, nhcNew *NodeHealthCheck
+	var nodeA, nodeB *v1.Node
+
+	newNode := func(name string, zone string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"zone": zone},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		nodeA = newNode("remediated-node-a", "a")
+		nodeB = newNode("healthy-node-b", "b")
+		Expect(k8sClient.Create(ctx, nodeA)).To(Succeed())
+		Expect(k8sClient.Create(ctx, nodeB)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, nodeA)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, nodeB)).To(Succeed())
+		})
+
+		mh := intstr.FromString("51%")
+		nhcOld = &NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: NodeHealthCheckSpec{
+				MinHealthy: &mh,
+				Selector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      "zone",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{"a", "b"},
+						},
+					},
+				},
+			},
+			Status: NodeHealthCheckStatus{
+				UnhealthyNodes: []*UnhealthyNode{
+					{
+						Name: nodeA.Name,
+						Remediations: []*Remediation{
+							{
+								Resource: v1.ObjectReference{Kind: "R1", Namespace: "dummy", Name: nodeA.Name},
+								Started:  metav1.Now(),
+							},
+						},
+					},
+				},
+			},
+		}
+		nhcNew = nhcOld.DeepCopy()
+	})
+
+	It("should be allowed when the remediating nodes stay selected", func() {
+		nhcNew.Spec.Selector.MatchExpressions[0].Values = []string{"a"}
+		Expect(nhcNew.validateSelectorUpdateDuringRemediation(ctx, k8sClient, nhcOld)).To(Succeed())
+	})
+
+	It("should be denied with the deselected remediating nodes", func() {
+		nhcNew.Spec.Selector.MatchExpressions[0].Values = []string{"b"}
+		Expect(nhcNew.validateSelectorUpdateDuringRemediation(ctx, k8sClient, nhcOld)).To(MatchError(
+			And(
+				ContainSubstring(OngoingRemediationError),
+				ContainSubstring("selector"),
+				ContainSubstring(nodeA.Name),
+				Not(ContainSubstring(nodeB.Name)),
+			),
+		))
+	})
+
+	It("should be allowed when remediation is deferred by the kubelet probe", func() {
+		nhcNew.Status.UnhealthyNodes[0].Remediations = nil
+		nhcNew.Status.UnhealthyNodes[0].KubeletProbe = &KubeletProbeResult{
+			Result: KubeletProbeResultResponding,
+			Since:  metav1.Now(),
+		}
+		nhcNew.Spec.Selector.MatchExpressions[0].Values = []string{"b"}
+		Expect(nhcNew.validateSelectorUpdateDuringRemediation(ctx, k8sClient, nhcOld)).To(Succeed())
+	})
+
+	It("should be allowed when the remediating node was deleted", func() {
+		nhcNew.Status.UnhealthyNodes[0].Name = "deleted-node"
+		nhcNew.Spec.Selector.MatchExpressions[0].Values = []string{"b"}
+		Expect(nhcNew.validateSelectorUpdateDuringRemediation(ctx, k8sClient, nhcOld)).To(Succeed())
+	})
+})
+
+var _ = DescribeTable("Selectors which might overlap",
+	func(s1, s2 metav1.LabelSelector, expected bool) {
+		selector1, err := metav1.LabelSelectorAsSelector(&s1)
+		Expect(err).ToNot(HaveOccurred())
+		selector2, err := metav1.LabelSelectorAsSelector(&s2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selectorsMightOverlap(selector1, selector2)).To(Equal(expected))
+		Expect(selectorsMightOverlap(selector2, selector1)).To(Equal(expected))
+	},
+	Entry("different keys",
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		metav1.LabelSelector{MatchLabels: map[string]string{"b": "1"}},
+		true),
+	Entry("same key and value",
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		true),
+	Entry("same key with different values",
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "2"}},
+		false),
+	Entry("exists and does not exist",
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpExists}}},
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpDoesNotExist}}},
+		false),
+	Entry("value and does not exist",
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpDoesNotExist}}},
+		false),
+	Entry("value in excluded values",
+		metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1", "2"}}}},
+		false),
+	Entry("values partly excluded",
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpIn, Values: []string{"1", "3"}}}},
+		metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1", "2"}}}},
+		true),
+)
@@ -0,0 +1,116 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SignalSeverity is the severity of a NodeHealthSignal
+type SignalSeverity string
+
+const (
+	// SignalSeverityInfo is used for signals which don't indicate a problem
+	SignalSeverityInfo SignalSeverity = "Info"
+	// SignalSeverityWarning is used for signals which indicate a degraded node
+	SignalSeverityWarning SignalSeverity = "Warning"
+	// SignalSeverityCritical is used for signals which indicate a failed node
+	SignalSeverityCritical SignalSeverity = "Critical"
+)
+
+// severityLevels is used for comparing severities
+var severityLevels = map[SignalSeverity]int{
+	SignalSeverityInfo:     0,
+	SignalSeverityWarning:  1,
+	SignalSeverityCritical: 2,
+}
+
+// IsAtLeast returns true if the severity is equal to or higher than the given severity
+func (s SignalSeverity) IsAtLeast(other SignalSeverity) bool {
+	level, known := severityLevels[s]
+	otherLevel, otherKnown := severityLevels[other]
+	return known && otherKnown && level >= otherLevel
+}
+
+// NodeHealthSignalSpec defines the desired state of NodeHealthSignal
+type NodeHealthSignalSpec struct {
+	// NodeName is the name of the node this signal is about.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeName string `json:"nodeName"`
+
+	// Source identifies the health agent which created this signal, e.g. "disk-smart".
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Source string `json:"source"`
+
+	// Severity of the signal, one of "Info", "Warning" or "Critical".
+	//
+	//+kubebuilder:validation:Enum=Info;Warning;Critical
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Severity SignalSeverity `json:"severity"`
+
+	// Message is a human-readable description of the signal.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Message string `json:"message,omitempty"`
+
+	// ExpiresAt is the time after which the signal is ignored. Health agents need to update it regularly,
+	// as long as the signal is valid. This prevents signals of failed agents to be considered forever.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// IsExpired returns true if the signal is expired at the given time
+func (s *NodeHealthSignal) IsExpired(now metav1.Time) bool {
+	return !s.Spec.ExpiresAt.After(now.Time)
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=nodehealthsignals,scope=Cluster,shortName=nhs
+//+kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.source`
+//+kubebuilder:printcolumn:name="Severity",type=string,JSONPath=`.spec.severity`
+//+kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.spec.expiresAt`
+
+// NodeHealthSignal is the Schema for the nodehealthsignals API.
+// It is created by external health agents, for reporting the health of a node to NodeHealthChecks.
+//
+// +operator-sdk:csv:customresourcedefinitions:resources={{"NodeHealthSignal","v1alpha1","nodehealthsignals"}}
+type NodeHealthSignal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeHealthSignalSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeHealthSignalList contains a list of NodeHealthSignal
+type NodeHealthSignalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeHealthSignal `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeHealthSignal{}, &NodeHealthSignalList{})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemediationBudgetName is the name of the RemediationBudget singleton. RemediationBudgets with other names are ignored.
+const RemediationBudgetName = "default"
+
+// RemediationBudgetSpec defines the desired state of RemediationBudget
+type RemediationBudgetSpec struct {
+	// MaxConcurrentRemediations is the maximum number of nodes which are remediated concurrently by all
+	// NodeHealthChecks together. When it isn't set, only the TemplateKindLimits apply.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxConcurrentRemediations *int `json:"maxConcurrentRemediations,omitempty"`
+
+	// TemplateKindLimits optionally limit the number of nodes which are remediated concurrently by all
+	// NodeHealthChecks together with remediation templates of the given kind.
+	//
+	//+optional
+	//+listType=map
+	//+listMapKey=kind
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	TemplateKindLimits []TemplateKindLimit `json:"templateKindLimits,omitempty"`
+}
+
+// TemplateKindLimit defines the maximum number of concurrent remediations with remediation templates of a kind
+type TemplateKindLimit struct {
+	// Kind is the kind of the remediation template, e.g. "Metal3RemediationTemplate".
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind string `json:"kind"`
+
+	// MaxConcurrentRemediations is the maximum number of nodes which are remediated concurrently with remediation
+	// templates of this kind.
+	//
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxConcurrentRemediations int `json:"maxConcurrentRemediations"`
+}
+
+// RemediationBudgetStatus defines the observed state of RemediationBudget
+type RemediationBudgetStatus struct {
+	// Remediations are the ongoing remediations of all NodeHealthChecks, which are counted by the budget.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Remediations []BudgetedRemediation `json:"remediations,omitempty"`
+}
+
+// BudgetedRemediation defines an ongoing remediation, which is counted by the RemediationBudget
+type BudgetedRemediation struct {
+	// NodeHealthCheck is the name of the NodeHealthCheck which remediates the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NodeHealthCheck string `json:"nodeHealthCheck"`
+
+	// NodeName is the name of the remediated node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NodeName string `json:"nodeName"`
+
+	// TemplateKind is the kind of the remediation template which is used for the current remediation of the node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TemplateKind string `json:"templateKind"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=remediationbudgets,scope=Cluster,shortName=rb
+//+kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxConcurrentRemediations`
+
+// RemediationBudget is the Schema for the remediationbudgets API.
+// It limits the number of concurrent remediations of all NodeHealthChecks together. Only the RemediationBudget
+// named "default" is used.
+//
+// +operator-sdk:csv:customresourcedefinitions:resources={{"RemediationBudget","v1alpha1","remediationbudgets"}}
+type RemediationBudget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemediationBudgetSpec   `json:"spec,omitempty"`
+	Status RemediationBudgetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RemediationBudgetList contains a list of RemediationBudget
+type RemediationBudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemediationBudget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemediationBudget{}, &RemediationBudgetList{})
+}
@@ -22,12 +22,27 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetedRemediation) DeepCopyInto(out *BudgetedRemediation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetedRemediation.
+func (in *BudgetedRemediation) DeepCopy() *BudgetedRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetedRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EscalatingRemediation) DeepCopyInto(out *EscalatingRemediation) {
 	*out = *in
@@ -45,6 +60,102 @@ func (in *EscalatingRemediation) DeepCopy() *EscalatingRemediation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedNode) DeepCopyInto(out *ExcludedNode) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedNode.
+func (in *ExcludedNode) DeepCopy() *ExcludedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomain) DeepCopyInto(out *FailureDomain) {
+	*out = *in
+	if in.AvailableRemediations != nil {
+		in, out := &in.AvailableRemediations, &out.AvailableRemediations
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomain.
+func (in *FailureDomain) DeepCopy() *FailureDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlappingDetection) DeepCopyInto(out *FlappingDetection) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlappingDetection.
+func (in *FlappingDetection) DeepCopy() *FlappingDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(FlappingDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletProbe) DeepCopyInto(out *KubeletProbe) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletProbe.
+func (in *KubeletProbe) DeepCopy() *KubeletProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletProbeResult) DeepCopyInto(out *KubeletProbeResult) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletProbeResult.
+func (in *KubeletProbeResult) DeepCopy() *KubeletProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeHealthCheck) DeepCopyInto(out *NodeHealthCheck) {
 	*out = *in
@@ -111,16 +222,65 @@ func (in *NodeHealthCheckSpec) DeepCopyInto(out *NodeHealthCheckSpec) {
 	if in.UnhealthyConditions != nil {
 		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
 		*out = make([]UnhealthyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaleLeaseDuration != nil {
+		in, out := &in.StaleLeaseDuration, &out.StaleLeaseDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyPods != nil {
+		in, out := &in.UnhealthyPods, &out.UnhealthyPods
+		*out = make([]UnhealthyPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthyTaints != nil {
+		in, out := &in.UnhealthyTaints, &out.UnhealthyTaints
+		*out = make([]UnhealthyTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthySignals != nil {
+		in, out := &in.UnhealthySignals, &out.UnhealthySignals
+		*out = make([]UnhealthySignal, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrometheusQuery != nil {
+		in, out := &in.PrometheusQuery, &out.PrometheusQuery
+		*out = new(PrometheusQuery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletProbe != nil {
+		in, out := &in.KubeletProbe, &out.KubeletProbe
+		*out = new(KubeletProbe)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MinHealthy != nil {
 		in, out := &in.MinHealthy, &out.MinHealthy
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(Topology)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RemediationTemplate != nil {
 		in, out := &in.RemediationTemplate, &out.RemediationTemplate
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.EscalatingRemediations != nil {
@@ -133,6 +293,26 @@ func (in *NodeHealthCheckSpec) DeepCopyInto(out *NodeHealthCheckSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HealthyStabilizationWindow != nil {
+		in, out := &in.HealthyStabilizationWindow, &out.HealthyStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FlappingDetection != nil {
+		in, out := &in.FlappingDetection, &out.FlappingDetection
+		*out = new(FlappingDetection)
+		**out = **in
+	}
+	if in.RemediationRateLimit != nil {
+		in, out := &in.RemediationRateLimit, &out.RemediationRateLimit
+		*out = new(RemediationRateLimit)
+		**out = **in
+	}
+	if in.StormDetection != nil {
+		in, out := &in.StormDetection, &out.StormDetection
+		*out = new(StormDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckSpec.
@@ -159,16 +339,40 @@ func (in *NodeHealthCheckStatus) DeepCopyInto(out *NodeHealthCheckStatus) {
 			}
 		}
 	}
+	if in.ExcludedNodes != nil {
+		in, out := &in.ExcludedNodes, &out.ExcludedNodes
+		*out = make([]ExcludedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]FailureDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Storm != nil {
+		in, out := &in.Storm, &out.Storm
+		*out = new(StormStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationRateLimit != nil {
+		in, out := &in.RemediationRateLimit, &out.RemediationRateLimit
+		*out = new(RemediationRateLimitStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InFlightRemediations != nil {
 		in, out := &in.InFlightRemediations, &out.InFlightRemediations
-		*out = make(map[string]metav1.Time, len(*in))
+		*out = make(map[string]v1.Time, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -185,6 +389,101 @@ func (in *NodeHealthCheckStatus) DeepCopy() *NodeHealthCheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthSignal) DeepCopyInto(out *NodeHealthSignal) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthSignal.
+func (in *NodeHealthSignal) DeepCopy() *NodeHealthSignal {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthSignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthSignal) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthSignalList) DeepCopyInto(out *NodeHealthSignalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeHealthSignal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthSignalList.
+func (in *NodeHealthSignalList) DeepCopy() *NodeHealthSignalList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthSignalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthSignalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthSignalSpec) DeepCopyInto(out *NodeHealthSignalSpec) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthSignalSpec.
+func (in *NodeHealthSignalSpec) DeepCopy() *NodeHealthSignalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthSignalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuery) DeepCopyInto(out *PrometheusQuery) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuery.
+func (in *PrometheusQuery) DeepCopy() *PrometheusQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Remediation) DeepCopyInto(out *Remediation) {
 	*out = *in
@@ -206,10 +505,268 @@ func (in *Remediation) DeepCopy() *Remediation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudget) DeepCopyInto(out *RemediationBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudget.
+func (in *RemediationBudget) DeepCopy() *RemediationBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemediationBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudgetList) DeepCopyInto(out *RemediationBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemediationBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudgetList.
+func (in *RemediationBudgetList) DeepCopy() *RemediationBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemediationBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudgetSpec) DeepCopyInto(out *RemediationBudgetSpec) {
+	*out = *in
+	if in.MaxConcurrentRemediations != nil {
+		in, out := &in.MaxConcurrentRemediations, &out.MaxConcurrentRemediations
+		*out = new(int)
+		**out = **in
+	}
+	if in.TemplateKindLimits != nil {
+		in, out := &in.TemplateKindLimits, &out.TemplateKindLimits
+		*out = make([]TemplateKindLimit, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudgetSpec.
+func (in *RemediationBudgetSpec) DeepCopy() *RemediationBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudgetStatus) DeepCopyInto(out *RemediationBudgetStatus) {
+	*out = *in
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]BudgetedRemediation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudgetStatus.
+func (in *RemediationBudgetStatus) DeepCopy() *RemediationBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRateLimit) DeepCopyInto(out *RemediationRateLimit) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRateLimit.
+func (in *RemediationRateLimit) DeepCopy() *RemediationRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRateLimitStatus) DeepCopyInto(out *RemediationRateLimitStatus) {
+	*out = *in
+	if in.RecentRemediations != nil {
+		in, out := &in.RecentRemediations, &out.RecentRemediations
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueuedNodes != nil {
+		in, out := &in.QueuedNodes, &out.QueuedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRemediationAt != nil {
+		in, out := &in.NextRemediationAt, &out.NextRemediationAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRateLimitStatus.
+func (in *RemediationRateLimitStatus) DeepCopy() *RemediationRateLimitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRateLimitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormDetection) DeepCopyInto(out *StormDetection) {
+	*out = *in
+	out.MaxNewUnhealthyNodes = in.MaxNewUnhealthyNodes
+	out.Window = in.Window
+	out.StabilizationPeriod = in.StabilizationPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormDetection.
+func (in *StormDetection) DeepCopy() *StormDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(StormDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormStatus) DeepCopyInto(out *StormStatus) {
+	*out = *in
+	if in.UnhealthyNodes != nil {
+		in, out := &in.UnhealthyNodes, &out.UnhealthyNodes
+		*out = make([]StormUnhealthyNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUnhealthyTransition != nil {
+		in, out := &in.LastUnhealthyTransition, &out.LastUnhealthyTransition
+		*out = (*in).DeepCopy()
+	}
+	if in.DetectedAt != nil {
+		in, out := &in.DetectedAt, &out.DetectedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormStatus.
+func (in *StormStatus) DeepCopy() *StormStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StormStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormUnhealthyNode) DeepCopyInto(out *StormUnhealthyNode) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormUnhealthyNode.
+func (in *StormUnhealthyNode) DeepCopy() *StormUnhealthyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(StormUnhealthyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateKindLimit) DeepCopyInto(out *TemplateKindLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateKindLimit.
+func (in *TemplateKindLimit) DeepCopy() *TemplateKindLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateKindLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Topology) DeepCopyInto(out *Topology) {
+	*out = *in
+	if in.MaxRemediationsPerDomain != nil {
+		in, out := &in.MaxRemediationsPerDomain, &out.MaxRemediationsPerDomain
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topology.
+func (in *Topology) DeepCopy() *Topology {
+	if in == nil {
+		return nil
+	}
+	out := new(Topology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnhealthyCondition) DeepCopyInto(out *UnhealthyCondition) {
 	*out = *in
 	out.Duration = in.Duration
+	if in.AbsentDuration != nil {
+		in, out := &in.AbsentDuration, &out.AbsentDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyCondition.
@@ -236,6 +793,26 @@ func (in *UnhealthyNode) DeepCopyInto(out *UnhealthyNode) {
 			}
 		}
 	}
+	if in.KubeletProbe != nil {
+		in, out := &in.KubeletProbe, &out.KubeletProbe
+		*out = new(KubeletProbeResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthySince != nil {
+		in, out := &in.HealthySince, &out.HealthySince
+		*out = (*in).DeepCopy()
+	}
+	if in.HealthyTransitions != nil {
+		in, out := &in.HealthyTransitions, &out.HealthyTransitions
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FlappingSince != nil {
+		in, out := &in.FlappingSince, &out.FlappingSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyNode.
@@ -247,3 +824,55 @@ func (in *UnhealthyNode) DeepCopy() *UnhealthyNode {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyPod) DeepCopyInto(out *UnhealthyPod) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyPod.
+func (in *UnhealthyPod) DeepCopy() *UnhealthyPod {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthySignal) DeepCopyInto(out *UnhealthySignal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthySignal.
+func (in *UnhealthySignal) DeepCopy() *UnhealthySignal {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthySignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyTaint) DeepCopyInto(out *UnhealthyTaint) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyTaint.
+func (in *UnhealthyTaint) DeepCopy() *UnhealthyTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyTaint)
+	in.DeepCopyInto(out)
+	return out
+}
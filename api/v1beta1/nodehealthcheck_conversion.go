@@ -0,0 +1,483 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+const (
+	// InFlightRemediationsAnnotationKey is used for preserving the deprecated v1alpha1 Status.InFlightRemediations
+	// field, which doesn't exist in v1beta1, during conversion round-trips
+	InFlightRemediationsAnnotationKey = "remediation.medik8s.io/v1alpha1-inflight-remediations"
+)
+
+var _ conversion.Convertible = &NodeHealthCheck{}
+
+// ConvertTo converts this NodeHealthCheck to the Hub version (v1alpha1).
+func (src *NodeHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.NodeHealthCheck)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// Spec
+	dst.Spec.Selector = *src.Spec.Selector.DeepCopy()
+	dst.Spec.UnhealthyConditions = nil
+	for _, uc := range src.Spec.UnhealthyConditions {
+		dst.Spec.UnhealthyConditions = append(dst.Spec.UnhealthyConditions, v1alpha1.UnhealthyCondition{
+			Type:           uc.Type,
+			Status:         uc.Status,
+			Duration:       uc.Duration,
+			AbsentPolicy:   v1alpha1.AbsentConditionPolicy(uc.AbsentPolicy),
+			AbsentDuration: uc.AbsentDuration.DeepCopy(),
+		})
+	}
+	dst.Spec.UnhealthyExpression = src.Spec.UnhealthyExpression
+	dst.Spec.StaleLeaseDuration = src.Spec.StaleLeaseDuration.DeepCopy()
+	dst.Spec.NodeStartupTimeout = src.Spec.NodeStartupTimeout.DeepCopy()
+	dst.Spec.UnhealthyTaints = nil
+	for _, ut := range src.Spec.UnhealthyTaints {
+		dst.Spec.UnhealthyTaints = append(dst.Spec.UnhealthyTaints, v1alpha1.UnhealthyTaint{
+			Key:      ut.Key,
+			Value:    ut.Value,
+			Effect:   ut.Effect,
+			Duration: ut.Duration,
+		})
+	}
+	dst.Spec.PrometheusQuery = nil
+	if pq := src.Spec.PrometheusQuery; pq != nil {
+		dst.Spec.PrometheusQuery = &v1alpha1.PrometheusQuery{
+			URL:                    pq.URL,
+			Query:                  pq.Query,
+			NodeLabel:              pq.NodeLabel,
+			Operator:               v1alpha1.ThresholdOperator(pq.Operator),
+			Threshold:              pq.Threshold,
+			Duration:               pq.Duration,
+			Interval:               pq.Interval.DeepCopy(),
+			InsecureSkipTLSVerify:  pq.InsecureSkipTLSVerify,
+			UseServiceAccountToken: pq.UseServiceAccountToken,
+		}
+	}
+	dst.Spec.KubeletProbe = nil
+	if kp := src.Spec.KubeletProbe; kp != nil {
+		dst.Spec.KubeletProbe = &v1alpha1.KubeletProbe{
+			Path:    kp.Path,
+			Scheme:  kp.Scheme,
+			Timeout: kp.Timeout.DeepCopy(),
+		}
+		if kp.Port != nil {
+			port := *kp.Port
+			dst.Spec.KubeletProbe.Port = &port
+		}
+	}
+	dst.Spec.UnhealthySignals = nil
+	for _, us := range src.Spec.UnhealthySignals {
+		dst.Spec.UnhealthySignals = append(dst.Spec.UnhealthySignals, v1alpha1.UnhealthySignal{
+			Source:      us.Source,
+			MinSeverity: v1alpha1.SignalSeverity(us.MinSeverity),
+		})
+	}
+	dst.Spec.UnhealthyPods = nil
+	for _, up := range src.Spec.UnhealthyPods {
+		dst.Spec.UnhealthyPods = append(dst.Spec.UnhealthyPods, v1alpha1.UnhealthyPod{
+			Namespace: up.Namespace,
+			Selector:  up.Selector.DeepCopy(),
+			DaemonSet: up.DaemonSet,
+			Duration:  up.Duration,
+		})
+	}
+	dst.Spec.MinHealthy = nil
+	if src.Spec.MinHealthy != nil {
+		minHealthy := *src.Spec.MinHealthy
+		dst.Spec.MinHealthy = &minHealthy
+	}
+	dst.Spec.MaxUnhealthy = nil
+	if src.Spec.MaxUnhealthy != nil {
+		maxUnhealthy := *src.Spec.MaxUnhealthy
+		dst.Spec.MaxUnhealthy = &maxUnhealthy
+	}
+	dst.Spec.UnhealthyRange = src.Spec.UnhealthyRange
+	dst.Spec.Topology = nil
+	if t := src.Spec.Topology; t != nil {
+		dst.Spec.Topology = &v1alpha1.Topology{Key: t.Key}
+		if t.MaxRemediationsPerDomain != nil {
+			maxRemediations := *t.MaxRemediationsPerDomain
+			dst.Spec.Topology.MaxRemediationsPerDomain = &maxRemediations
+		}
+	}
+	dst.Spec.RemediationTemplate = src.Spec.RemediationTemplate.DeepCopy()
+	dst.Spec.EscalatingRemediations = nil
+	for _, er := range src.Spec.EscalatingRemediations {
+		dst.Spec.EscalatingRemediations = append(dst.Spec.EscalatingRemediations, v1alpha1.EscalatingRemediation{
+			RemediationTemplate: er.RemediationTemplate,
+			Order:               er.Order,
+			Timeout:             er.Timeout,
+		})
+	}
+	dst.Spec.PauseRequests = append([]string(nil), src.Spec.PauseRequests...)
+	dst.Spec.DeletionPolicy = v1alpha1.DeletionPolicy(src.Spec.DeletionPolicy)
+	dst.Spec.NodeDeletionPolicy = v1alpha1.NodeDeletionPolicy(src.Spec.NodeDeletionPolicy)
+	dst.Spec.DeselectedNodePolicy = v1alpha1.DeselectedNodePolicy(src.Spec.DeselectedNodePolicy)
+	dst.Spec.HealthyStabilizationWindow = src.Spec.HealthyStabilizationWindow.DeepCopy()
+	dst.Spec.FlappingDetection = nil
+	if fd := src.Spec.FlappingDetection; fd != nil {
+		dst.Spec.FlappingDetection = &v1alpha1.FlappingDetection{
+			MaxTransitions: fd.MaxTransitions,
+			Window:         fd.Window,
+			Action:         v1alpha1.FlappingAction(fd.Action),
+		}
+	}
+	dst.Spec.RemediationRateLimit = nil
+	if rl := src.Spec.RemediationRateLimit; rl != nil {
+		dst.Spec.RemediationRateLimit = &v1alpha1.RemediationRateLimit{
+			MaxRemediations: rl.MaxRemediations,
+			Window:          rl.Window,
+		}
+	}
+	dst.Spec.StormDetection = nil
+	if sd := src.Spec.StormDetection; sd != nil {
+		dst.Spec.StormDetection = &v1alpha1.StormDetection{
+			MaxNewUnhealthyNodes: sd.MaxNewUnhealthyNodes,
+			Window:               sd.Window,
+			StabilizationPeriod:  sd.StabilizationPeriod,
+		}
+	}
+
+	// Status
+	dst.Status.ObservedNodes = src.Status.ObservedNodes
+	dst.Status.HealthyNodes = src.Status.HealthyNodes
+	dst.Status.UnhealthyNodes = nil
+	for _, un := range src.Status.UnhealthyNodes {
+		if un == nil {
+			continue
+		}
+		dstNode := &v1alpha1.UnhealthyNode{Name: un.Name, UID: un.UID}
+		if un.KubeletProbe != nil {
+			dstNode.KubeletProbe = &v1alpha1.KubeletProbeResult{
+				Result:  v1alpha1.KubeletProbeResultType(un.KubeletProbe.Result),
+				Since:   un.KubeletProbe.Since,
+				Message: un.KubeletProbe.Message,
+			}
+		}
+		dstNode.HealthySince = un.HealthySince.DeepCopy()
+		dstNode.HealthyTransitions = append([]metav1.Time(nil), un.HealthyTransitions...)
+		dstNode.FlappingSince = un.FlappingSince.DeepCopy()
+		for _, rem := range un.Remediations {
+			if rem == nil {
+				continue
+			}
+			dstNode.Remediations = append(dstNode.Remediations, &v1alpha1.Remediation{
+				Resource: rem.Resource,
+				Started:  rem.Started,
+				TimedOut: rem.TimedOut.DeepCopy(),
+			})
+		}
+		dst.Status.UnhealthyNodes = append(dst.Status.UnhealthyNodes, dstNode)
+	}
+	dst.Status.ExcludedNodes = nil
+	for _, en := range src.Status.ExcludedNodes {
+		dst.Status.ExcludedNodes = append(dst.Status.ExcludedNodes, v1alpha1.ExcludedNode{Name: en.Name, Until: en.Until.DeepCopy()})
+	}
+	dst.Status.FailureDomains = nil
+	for _, domain := range src.Status.FailureDomains {
+		dstDomain := v1alpha1.FailureDomain{
+			Name:             domain.Name,
+			ObservedNodes:    domain.ObservedNodes,
+			HealthyNodes:     domain.HealthyNodes,
+			MinHealthy:       domain.MinHealthy,
+			RemediatingNodes: domain.RemediatingNodes,
+		}
+		if domain.AvailableRemediations != nil {
+			available := *domain.AvailableRemediations
+			dstDomain.AvailableRemediations = &available
+		}
+		dst.Status.FailureDomains = append(dst.Status.FailureDomains, dstDomain)
+	}
+	dst.Status.Storm = nil
+	if storm := src.Status.Storm; storm != nil {
+		dst.Status.Storm = &v1alpha1.StormStatus{
+			LastUnhealthyTransition: storm.LastUnhealthyTransition.DeepCopy(),
+			DetectedAt:              storm.DetectedAt.DeepCopy(),
+		}
+		for _, un := range storm.UnhealthyNodes {
+			dst.Status.Storm.UnhealthyNodes = append(dst.Status.Storm.UnhealthyNodes, v1alpha1.StormUnhealthyNode{Name: un.Name, Since: un.Since.DeepCopy()})
+		}
+	}
+	dst.Status.RemediationRateLimit = nil
+	if rl := src.Status.RemediationRateLimit; rl != nil {
+		dst.Status.RemediationRateLimit = &v1alpha1.RemediationRateLimitStatus{
+			RecentRemediations: append([]metav1.Time(nil), rl.RecentRemediations...),
+			QueuedNodes:        append([]string(nil), rl.QueuedNodes...),
+			NextRemediationAt:  rl.NextRemediationAt.DeepCopy(),
+		}
+	}
+	dst.Status.Conditions = nil
+	for _, c := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, *c.DeepCopy())
+	}
+	dst.Status.Phase = v1alpha1.NHCPhase(src.Status.Phase)
+	dst.Status.Reason = src.Status.Reason
+
+	// restore fields which only exist in the hub
+	dst.Status.InFlightRemediations = nil
+	if value, exists := dst.GetAnnotations()[InFlightRemediationsAnnotationKey]; exists {
+		inFlight := make(map[string]metav1.Time)
+		if err := json.Unmarshal([]byte(value), &inFlight); err != nil {
+			return fmt.Errorf("failed to restore InFlightRemediations from annotation: %v", err)
+		}
+		if len(inFlight) > 0 {
+			dst.Status.InFlightRemediations = inFlight
+		}
+		delete(dst.Annotations, InFlightRemediationsAnnotationKey)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *NodeHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.NodeHealthCheck)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// Spec
+	dst.Spec.Selector = *src.Spec.Selector.DeepCopy()
+	dst.Spec.UnhealthyConditions = nil
+	for _, uc := range src.Spec.UnhealthyConditions {
+		dst.Spec.UnhealthyConditions = append(dst.Spec.UnhealthyConditions, UnhealthyCondition{
+			Type:           uc.Type,
+			Status:         uc.Status,
+			Duration:       uc.Duration,
+			AbsentPolicy:   AbsentConditionPolicy(uc.AbsentPolicy),
+			AbsentDuration: uc.AbsentDuration.DeepCopy(),
+		})
+	}
+	dst.Spec.UnhealthyExpression = src.Spec.UnhealthyExpression
+	dst.Spec.StaleLeaseDuration = src.Spec.StaleLeaseDuration.DeepCopy()
+	dst.Spec.NodeStartupTimeout = src.Spec.NodeStartupTimeout.DeepCopy()
+	dst.Spec.UnhealthyTaints = nil
+	for _, ut := range src.Spec.UnhealthyTaints {
+		dst.Spec.UnhealthyTaints = append(dst.Spec.UnhealthyTaints, UnhealthyTaint{
+			Key:      ut.Key,
+			Value:    ut.Value,
+			Effect:   ut.Effect,
+			Duration: ut.Duration,
+		})
+	}
+	dst.Spec.PrometheusQuery = nil
+	if pq := src.Spec.PrometheusQuery; pq != nil {
+		dst.Spec.PrometheusQuery = &PrometheusQuery{
+			URL:                    pq.URL,
+			Query:                  pq.Query,
+			NodeLabel:              pq.NodeLabel,
+			Operator:               ThresholdOperator(pq.Operator),
+			Threshold:              pq.Threshold,
+			Duration:               pq.Duration,
+			Interval:               pq.Interval.DeepCopy(),
+			InsecureSkipTLSVerify:  pq.InsecureSkipTLSVerify,
+			UseServiceAccountToken: pq.UseServiceAccountToken,
+		}
+	}
+	dst.Spec.KubeletProbe = nil
+	if kp := src.Spec.KubeletProbe; kp != nil {
+		dst.Spec.KubeletProbe = &KubeletProbe{
+			Path:    kp.Path,
+			Scheme:  kp.Scheme,
+			Timeout: kp.Timeout.DeepCopy(),
+		}
+		if kp.Port != nil {
+			port := *kp.Port
+			dst.Spec.KubeletProbe.Port = &port
+		}
+	}
+	dst.Spec.UnhealthySignals = nil
+	for _, us := range src.Spec.UnhealthySignals {
+		dst.Spec.UnhealthySignals = append(dst.Spec.UnhealthySignals, UnhealthySignal{
+			Source:      us.Source,
+			MinSeverity: SignalSeverity(us.MinSeverity),
+		})
+	}
+	dst.Spec.UnhealthyPods = nil
+	for _, up := range src.Spec.UnhealthyPods {
+		dst.Spec.UnhealthyPods = append(dst.Spec.UnhealthyPods, UnhealthyPod{
+			Namespace: up.Namespace,
+			Selector:  up.Selector.DeepCopy(),
+			DaemonSet: up.DaemonSet,
+			Duration:  up.Duration,
+		})
+	}
+	dst.Spec.MinHealthy = nil
+	if src.Spec.MinHealthy != nil {
+		minHealthy := *src.Spec.MinHealthy
+		dst.Spec.MinHealthy = &minHealthy
+	}
+	dst.Spec.MaxUnhealthy = nil
+	if src.Spec.MaxUnhealthy != nil {
+		maxUnhealthy := *src.Spec.MaxUnhealthy
+		dst.Spec.MaxUnhealthy = &maxUnhealthy
+	}
+	dst.Spec.UnhealthyRange = src.Spec.UnhealthyRange
+	dst.Spec.Topology = nil
+	if t := src.Spec.Topology; t != nil {
+		dst.Spec.Topology = &Topology{Key: t.Key}
+		if t.MaxRemediationsPerDomain != nil {
+			maxRemediations := *t.MaxRemediationsPerDomain
+			dst.Spec.Topology.MaxRemediationsPerDomain = &maxRemediations
+		}
+	}
+	dst.Spec.RemediationTemplate = src.Spec.RemediationTemplate.DeepCopy()
+	dst.Spec.EscalatingRemediations = nil
+	for _, er := range src.Spec.EscalatingRemediations {
+		dst.Spec.EscalatingRemediations = append(dst.Spec.EscalatingRemediations, EscalatingRemediation{
+			RemediationTemplate: er.RemediationTemplate,
+			Order:               er.Order,
+			Timeout:             er.Timeout,
+		})
+	}
+	dst.Spec.PauseRequests = append([]string(nil), src.Spec.PauseRequests...)
+	dst.Spec.DeletionPolicy = DeletionPolicy(src.Spec.DeletionPolicy)
+	dst.Spec.NodeDeletionPolicy = NodeDeletionPolicy(src.Spec.NodeDeletionPolicy)
+	dst.Spec.DeselectedNodePolicy = DeselectedNodePolicy(src.Spec.DeselectedNodePolicy)
+	dst.Spec.HealthyStabilizationWindow = src.Spec.HealthyStabilizationWindow.DeepCopy()
+	dst.Spec.FlappingDetection = nil
+	if fd := src.Spec.FlappingDetection; fd != nil {
+		dst.Spec.FlappingDetection = &FlappingDetection{
+			MaxTransitions: fd.MaxTransitions,
+			Window:         fd.Window,
+			Action:         FlappingAction(fd.Action),
+		}
+	}
+	dst.Spec.RemediationRateLimit = nil
+	if rl := src.Spec.RemediationRateLimit; rl != nil {
+		dst.Spec.RemediationRateLimit = &RemediationRateLimit{
+			MaxRemediations: rl.MaxRemediations,
+			Window:          rl.Window,
+		}
+	}
+	dst.Spec.StormDetection = nil
+	if sd := src.Spec.StormDetection; sd != nil {
+		dst.Spec.StormDetection = &StormDetection{
+			MaxNewUnhealthyNodes: sd.MaxNewUnhealthyNodes,
+			Window:               sd.Window,
+			StabilizationPeriod:  sd.StabilizationPeriod,
+		}
+	}
+
+	// Status
+	dst.Status.ObservedNodes = src.Status.ObservedNodes
+	dst.Status.HealthyNodes = src.Status.HealthyNodes
+	dst.Status.UnhealthyNodes = nil
+	for _, un := range src.Status.UnhealthyNodes {
+		if un == nil {
+			continue
+		}
+		dstNode := &UnhealthyNode{Name: un.Name, UID: un.UID}
+		if un.KubeletProbe != nil {
+			dstNode.KubeletProbe = &KubeletProbeResult{
+				Result:  KubeletProbeResultType(un.KubeletProbe.Result),
+				Since:   un.KubeletProbe.Since,
+				Message: un.KubeletProbe.Message,
+			}
+		}
+		dstNode.HealthySince = un.HealthySince.DeepCopy()
+		dstNode.HealthyTransitions = append([]metav1.Time(nil), un.HealthyTransitions...)
+		dstNode.FlappingSince = un.FlappingSince.DeepCopy()
+		for _, rem := range un.Remediations {
+			if rem == nil {
+				continue
+			}
+			dstNode.Remediations = append(dstNode.Remediations, &Remediation{
+				Resource: rem.Resource,
+				Started:  rem.Started,
+				TimedOut: rem.TimedOut.DeepCopy(),
+			})
+		}
+		dst.Status.UnhealthyNodes = append(dst.Status.UnhealthyNodes, dstNode)
+	}
+	dst.Status.ExcludedNodes = nil
+	for _, en := range src.Status.ExcludedNodes {
+		dst.Status.ExcludedNodes = append(dst.Status.ExcludedNodes, ExcludedNode{Name: en.Name, Until: en.Until.DeepCopy()})
+	}
+	dst.Status.FailureDomains = nil
+	for _, domain := range src.Status.FailureDomains {
+		dstDomain := FailureDomain{
+			Name:             domain.Name,
+			ObservedNodes:    domain.ObservedNodes,
+			HealthyNodes:     domain.HealthyNodes,
+			MinHealthy:       domain.MinHealthy,
+			RemediatingNodes: domain.RemediatingNodes,
+		}
+		if domain.AvailableRemediations != nil {
+			available := *domain.AvailableRemediations
+			dstDomain.AvailableRemediations = &available
+		}
+		dst.Status.FailureDomains = append(dst.Status.FailureDomains, dstDomain)
+	}
+	dst.Status.Storm = nil
+	if storm := src.Status.Storm; storm != nil {
+		dst.Status.Storm = &StormStatus{
+			LastUnhealthyTransition: storm.LastUnhealthyTransition.DeepCopy(),
+			DetectedAt:              storm.DetectedAt.DeepCopy(),
+		}
+		for _, un := range storm.UnhealthyNodes {
+			dst.Status.Storm.UnhealthyNodes = append(dst.Status.Storm.UnhealthyNodes, StormUnhealthyNode{Name: un.Name, Since: un.Since.DeepCopy()})
+		}
+	}
+	dst.Status.RemediationRateLimit = nil
+	if rl := src.Status.RemediationRateLimit; rl != nil {
+		dst.Status.RemediationRateLimit = &RemediationRateLimitStatus{
+			RecentRemediations: append([]metav1.Time(nil), rl.RecentRemediations...),
+			QueuedNodes:        append([]string(nil), rl.QueuedNodes...),
+			NextRemediationAt:  rl.NextRemediationAt.DeepCopy(),
+		}
+	}
+	dst.Status.Conditions = nil
+	for _, c := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, *c.DeepCopy())
+	}
+	dst.Status.Phase = NHCPhase(src.Status.Phase)
+	dst.Status.Reason = src.Status.Reason
+
+	// preserve fields which don't exist in this version
+	if len(src.Status.InFlightRemediations) > 0 {
+		value, err := json.Marshal(src.Status.InFlightRemediations)
+		if err != nil {
+			return fmt.Errorf("failed to preserve InFlightRemediations in annotation: %v", err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string, 1)
+		}
+		dst.Annotations[InFlightRemediationsAnnotationKey] = string(value)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package v1beta1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("NodeHealthCheck Conversion", func() {
+
+	var hub *v1alpha1.NodeHealthCheck
+
+	BeforeEach(func() {
+		mh := intstr.FromString("51%")
+		mu := intstr.FromInt(5)
+		// use second precision, that's what survives serialization
+		started := metav1.NewTime(time.Now().Truncate(time.Second))
+		timedOut := metav1.NewTime(started.Add(time.Minute))
+		hub = &v1alpha1.NodeHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: v1alpha1.NodeHealthCheckSpec{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				UnhealthyConditions: []v1alpha1.UnhealthyCondition{
+					{
+						Type:     v1.NodeReady,
+						Status:   v1.ConditionUnknown,
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+					{
+						Type:           "KernelDeadlock",
+						Status:         v1.ConditionTrue,
+						Duration:       metav1.Duration{Duration: 5 * time.Minute},
+						AbsentPolicy:   v1alpha1.AbsentConditionPolicyUnhealthy,
+						AbsentDuration: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				UnhealthyExpression: `labels["zone"] == "a"`,
+				StaleLeaseDuration:  &metav1.Duration{Duration: 40 * time.Second},
+				NodeStartupTimeout:  &metav1.Duration{Duration: 10 * time.Minute},
+				UnhealthyPods: []v1alpha1.UnhealthyPod{
+					{
+						Namespace: "openshift-sdn",
+						DaemonSet: "sdn",
+						Duration:  metav1.Duration{Duration: 10 * time.Minute},
+					},
+					{
+						Namespace: "storage",
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "csi-node"},
+						},
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				UnhealthyTaints: []v1alpha1.UnhealthyTaint{
+					{
+						Key:      "node.kubernetes.io/unreachable",
+						Effect:   v1.TaintEffectNoExecute,
+						Duration: metav1.Duration{Duration: 2 * time.Minute},
+					},
+				},
+				UnhealthySignals: []v1alpha1.UnhealthySignal{
+					{
+						Source:      "disk-smart",
+						MinSeverity: v1alpha1.SignalSeverityWarning,
+					},
+				},
+				PrometheusQuery: &v1alpha1.PrometheusQuery{
+					URL:                    "https://thanos-querier.openshift-monitoring.svc:9091",
+					Query:                  "node_filesystem_avail_bytes",
+					NodeLabel:              "instance",
+					Operator:               v1alpha1.ThresholdOperatorLessThan,
+					Threshold:              "1000000",
+					Duration:               metav1.Duration{Duration: 5 * time.Minute},
+					Interval:               &metav1.Duration{Duration: time.Minute},
+					InsecureSkipTLSVerify:  true,
+					UseServiceAccountToken: true,
+				},
+				KubeletProbe: &v1alpha1.KubeletProbe{
+					Port:    pointer.Int32(10250),
+					Path:    "/healthz",
+					Scheme:  v1.URISchemeHTTPS,
+					Timeout: &metav1.Duration{Duration: 5 * time.Second},
+				},
+				HealthyStabilizationWindow: &metav1.Duration{Duration: 2 * time.Minute},
+				FlappingDetection: &v1alpha1.FlappingDetection{
+					MaxTransitions: 3,
+					Window:         metav1.Duration{Duration: time.Hour},
+					Action:         v1alpha1.FlappingActionEscalate,
+				},
+				RemediationRateLimit: &v1alpha1.RemediationRateLimit{
+					MaxRemediations: 2,
+					Window:          metav1.Duration{Duration: time.Hour},
+				},
+				StormDetection: &v1alpha1.StormDetection{
+					MaxNewUnhealthyNodes: intstr.FromString("30%"),
+					Window:               metav1.Duration{Duration: 5 * time.Minute},
+					StabilizationPeriod:  metav1.Duration{Duration: 10 * time.Minute},
+				},
+				MinHealthy:     &mh,
+				MaxUnhealthy:   &mu,
+				UnhealthyRange: "[1-10]",
+				Topology: &v1alpha1.Topology{
+					Key:                      "topology.kubernetes.io/zone",
+					MaxRemediationsPerDomain: pointer.Int(1),
+				},
+				EscalatingRemediations: []v1alpha1.EscalatingRemediation{
+					{
+						RemediationTemplate: v1.ObjectReference{Kind: "R1Template", Namespace: "dummy", Name: "r1", APIVersion: "r1"},
+						Order:               1,
+						Timeout:             metav1.Duration{Duration: 2 * time.Minute},
+					},
+				},
+				PauseRequests:        []string{"upgrade"},
+				DeletionPolicy:       v1alpha1.DeletionPolicyOrphan,
+				NodeDeletionPolicy:   v1alpha1.NodeDeletionPolicyOrphan,
+				DeselectedNodePolicy: v1alpha1.DeselectedNodePolicyCancel,
+			},
+			Status: v1alpha1.NodeHealthCheckStatus{
+				ObservedNodes: 3,
+				HealthyNodes:  2,
+				UnhealthyNodes: []*v1alpha1.UnhealthyNode{
+					{
+						Name: "node1",
+						UID:  "node1-uid",
+						KubeletProbe: &v1alpha1.KubeletProbeResult{
+							Result:  v1alpha1.KubeletProbeResultNotResponding,
+							Since:   started,
+							Message: "connection refused",
+						},
+						HealthySince:       &timedOut,
+						HealthyTransitions: []metav1.Time{started, timedOut},
+						FlappingSince:      &timedOut,
+						Remediations: []*v1alpha1.Remediation{
+							{
+								Resource: v1.ObjectReference{Kind: "R1", Namespace: "dummy", Name: "node1", APIVersion: "r1"},
+								Started:  started,
+								TimedOut: &timedOut,
+							},
+						},
+					},
+				},
+				ExcludedNodes: []v1alpha1.ExcludedNode{
+					{Name: "node2"},
+					{Name: "node3", Until: &timedOut},
+				},
+				FailureDomains: []v1alpha1.FailureDomain{
+					{
+						Name:                  "zone-a",
+						ObservedNodes:         3,
+						HealthyNodes:          2,
+						MinHealthy:            2,
+						RemediatingNodes:      1,
+						AvailableRemediations: pointer.Int(0),
+					},
+				},
+				Storm: &v1alpha1.StormStatus{
+					UnhealthyNodes: []v1alpha1.StormUnhealthyNode{
+						{Name: "node1", Since: &started},
+						{Name: "node2"},
+					},
+					LastUnhealthyTransition: &started,
+					DetectedAt:              &timedOut,
+				},
+				RemediationRateLimit: &v1alpha1.RemediationRateLimitStatus{
+					RecentRemediations: []metav1.Time{started},
+					QueuedNodes:        []string{"node2"},
+					NextRemediationAt:  &timedOut,
+				},
+				InFlightRemediations: map[string]metav1.Time{
+					"node1": started,
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:   v1alpha1.ConditionTypeDisabled,
+						Status: metav1.ConditionFalse,
+						Reason: v1alpha1.ConditionReasonEnabled,
+					},
+				},
+				Phase:  v1alpha1.PhasePaused,
+				Reason: "paused",
+			},
+		}
+	})
+
+	Context("Converting from the hub and back", func() {
+		It("should not lose any data", func() {
+			spoke := &NodeHealthCheck{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			Expect(spoke.Spec.EscalatingRemediations).To(HaveLen(1))
+			Expect(spoke.Status.UnhealthyNodes).To(HaveLen(1))
+			Expect(spoke.Annotations).To(HaveKey(InFlightRemediationsAnnotationKey))
+
+			restored := &v1alpha1.NodeHealthCheck{}
+			Expect(spoke.ConvertTo(restored)).To(Succeed())
+			Expect(restored).To(Equal(hub))
+		})
+	})
+
+	Context("Converting to the hub and back", func() {
+		It("should not lose any data", func() {
+			hub.Status.InFlightRemediations = nil
+			spoke := &NodeHealthCheck{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			Expect(spoke.Annotations).ToNot(HaveKey(InFlightRemediationsAnnotationKey))
+
+			converted := &v1alpha1.NodeHealthCheck{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			restored := &NodeHealthCheck{}
+			Expect(restored.ConvertFrom(converted)).To(Succeed())
+			Expect(restored).To(Equal(spoke))
+		})
+	})
+
+	Context("Converting into a reused object", func() {
+		It("should not keep stale values", func() {
+			spoke := &NodeHealthCheck{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			Expect(spoke.Spec.MinHealthy).ToNot(BeNil())
+
+			hub.Spec.MinHealthy = nil
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+			Expect(spoke.Spec.MinHealthy).To(BeNil())
+
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.MinHealthy).To(BeNil())
+		})
+	})
+})
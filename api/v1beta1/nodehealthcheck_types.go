@@ -0,0 +1,1072 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NHCPhase is the string used for NHC.Status.Phase
+type NHCPhase string
+
+const (
+	// PhaseTerminating is used when the NHC is being deleted
+	PhaseTerminating NHCPhase = "Terminating"
+
+	// PhaseDisabled is used when not terminating, and the Disabled condition is true
+	PhaseDisabled NHCPhase = "Disabled"
+
+	// PhasePaused is used when not disabled, but PauseRequests is set
+	PhasePaused NHCPhase = "Paused"
+
+	// PhaseRemediating is used when not disabled and not paused, and UnhealthyNodes is set
+	PhaseRemediating NHCPhase = "Remediating"
+
+	// PhaseEnabled is used in all other cases
+	PhaseEnabled NHCPhase = "Enabled"
+)
+
+// NodeHealthCheckSpec defines the desired state of NodeHealthCheck
+type NodeHealthCheckSpec struct {
+	// Label selector to match nodes whose health will be exercised.
+	//
+	// Selecting both control-plane and worker nodes in one NHC CR is
+	// highly discouraged and can result in undesired behaviour.
+	//
+	//+kubebuilder:validation:Required
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// UnhealthyConditions contains a list of the conditions that determine
+	// whether a node is considered unhealthy.  The conditions are combined in a
+	// logical OR, i.e. if any of the conditions is met, the node is unhealthy.
+	//
+	//+optional
+	//+patchStrategy=merge
+	//+patchMergeKey=type
+	//+kubebuilder:default:={{type:Ready,status:False,duration:"300s"},{type:Ready,status:Unknown,duration:"300s"}}
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+	// UnhealthyExpression is an optional CEL expression which identifies unhealthy nodes, in addition to
+	// UnhealthyConditions. It has access to these variables:
+	// "conditions", a list of the node's conditions, with type, status, reason, message and lastTransitionTime fields;
+	// "labels", a map of the node's labels;
+	// "taints", a list of the node's taints, with key, value and effect fields;
+	// "now", the current time.
+	// The expression needs to evaluate to true for unhealthy nodes, e.g.
+	// 'conditions.exists(c, c.type == "Ready" && c.status == "Unknown" && now - c.lastTransitionTime > duration("2m"))'
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyExpression string `json:"unhealthyExpression,omitempty"`
+
+	// StaleLeaseDuration is an optional duration after which a node is considered unhealthy, when its Lease
+	// in the kube-node-lease namespace wasn't renewed. Kubelet renews its Lease regularly, so a stale Lease
+	// indicates a failing node earlier than the node's Ready condition. Nodes without a Lease are ignored.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StaleLeaseDuration *metav1.Duration `json:"staleLeaseDuration,omitempty"`
+
+	// NodeStartupTimeout is an optional duration after which a node is considered unhealthy, when it didn't
+	// report Ready=True since its creation. This catches nodes which join the cluster, but never become Ready.
+	// Nodes whose Ready condition changed after the timeout are handled by the UnhealthyConditions only.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// UnhealthyPods contains a list of pods, which indicate an unhealthy node when they are not ready on it
+	// for the given duration. This is useful for critical DaemonSet pods, e.g. of CNI or storage providers,
+	// which might fail while the node still is ready.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyPods []UnhealthyPod `json:"unhealthyPods,omitempty"`
+
+	// UnhealthyTaints contains a list of taints, which make a node unhealthy when they exist for the given duration.
+	// The duration is measured from the taint's timeAdded field, or from when the taint was seen first if it
+	// has no timeAdded. Examples are the node.kubernetes.io/unreachable taint of the node lifecycle controller,
+	// or taints of vendor specific health checks.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyTaints []UnhealthyTaint `json:"unhealthyTaints,omitempty"`
+
+	// UnhealthySignals contains a list of NodeHealthSignal sources, whose signals make a node unhealthy.
+	// NodeHealthSignals are created by external health agents for reporting node health problems, which
+	// can't be expressed with node conditions. Expired signals are ignored.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthySignals []UnhealthySignal `json:"unhealthySignals,omitempty"`
+
+	// PrometheusQuery optionally configures a PromQL query, whose results identify unhealthy nodes.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PrometheusQuery *PrometheusQuery `json:"prometheusQuery,omitempty"`
+
+	// KubeletProbe optionally configures probing the kubelet of nodes which fail the UnhealthyConditions.
+	// When the kubelet responds, the node is probably only disconnected from the API server, and its remediation
+	// is deferred. When the kubelet doesn't respond, the node is confirmed to be unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	KubeletProbe *KubeletProbe `json:"kubeletProbe,omitempty"`
+
+	// Remediation is allowed if at least "MinHealthy" nodes selected by "selector" are healthy.
+	// Expects either a positive integer value or a percentage value.
+	// Percentage values must be positive whole numbers and are capped at 100%.
+	// 100% is valid and will block all remediation.
+	//
+	//+kubebuilder:default="51%"
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinHealthy *intstr.IntOrString `json:"minHealthy,omitempty"`
+
+	// MaxUnhealthy is the maximum number of nodes selected by "selector" which are remediated concurrently.
+	// New remediations are only started while less nodes are under remediation.
+	// Expects either a positive integer value or a percentage value.
+	// Percentage values must be positive whole numbers and are capped at 100%.
+	// 0 is valid and will block all new remediation.
+	//
+	//+optional
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// UnhealthyRange is the allowed range of unhealthy nodes selected by "selector", formatted as "[min-max]".
+	// Remediation is only allowed if the number of unhealthy nodes is within this range, e.g. "[1-5]".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^\\[[0-9]+-[0-9]+\\]$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	UnhealthyRange string `json:"unhealthyRange,omitempty"`
+
+	// Topology optionally configures failure domains, like zones or racks. MinHealthy applies within each domain
+	// instead of all selected nodes then, and the number of concurrent remediations per domain can be limited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Topology *Topology `json:"topology,omitempty"`
+
+	// RemediationTemplate is a reference to a remediation template
+	// provided by an infrastructure provider.
+	//
+	// If a node needs remediation the controller will create an object from this template
+	// and then it should be picked up by a remediation provider.
+	//
+	// Mutually exclusive with EscalatingRemediations
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
+
+	// EscalatingRemediations contain a list of ordered remediation templates with a timeout.
+	// The remediation templates will be used one after another, until the unhealthy node
+	// gets healthy within the timeout of the currently processed remediation. The order of
+	// remediation is defined by the "order" field of each "escalatingRemediation".
+	//
+	// Mutually exclusive with RemediationTemplate
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	EscalatingRemediations []EscalatingRemediation `json:"escalatingRemediations,omitempty"`
+
+	// PauseRequests will prevent any new remediation to start, while in-flight remediations
+	// keep running. Each entry is free form, and ideally represents the requested party reason
+	// for this pausing - i.e:
+	//     "imaginary-cluster-upgrade-manager-operator"
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	PauseRequests []string `json:"pauseRequests,omitempty"`
+
+	// DeletionPolicy defines what happens with ongoing remediations when the NodeHealthCheck is deleted.
+	// "Wait" waits for ongoing remediations to finish, without starting new remediations.
+	// "Cancel" deletes the remediation CRs of ongoing remediations.
+	// "Orphan" keeps ongoing remediations running, by removing the owner reference to this NodeHealthCheck
+	// from the remediation CRs.
+	//
+	//+optional
+	//+kubebuilder:default=Wait
+	//+kubebuilder:validation:Enum=Wait;Cancel;Orphan
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// NodeDeletionPolicy defines what happens with leftover remediation CRs of nodes which were deleted, e.g. by
+	// a remediator which replaces machines, or by a scale down. The remediation of deleted nodes is considered
+	// as completed, and they are removed from the status.
+	// "Delete" deletes the remediation CRs.
+	// "Orphan" keeps the remediation CRs, by removing the owner reference to this NodeHealthCheck from them.
+	//
+	//+optional
+	//+kubebuilder:default=Delete
+	//+kubebuilder:validation:Enum=Delete;Orphan
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeDeletionPolicy NodeDeletionPolicy `json:"nodeDeletionPolicy,omitempty"`
+
+	// DeselectedNodePolicy defines what happens with ongoing remediations of nodes which aren't selected by the
+	// selector anymore, e.g. because their labels were changed.
+	// "Finish" keeps the remediation CRs until the node is healthy again, or until the current remediation
+	// timed out, without escalating to other remediators.
+	// "Cancel" deletes the remediation CRs immediately.
+	// In both cases the node is removed from the status afterwards.
+	//
+	//+optional
+	//+kubebuilder:default=Finish
+	//+kubebuilder:validation:Enum=Finish;Cancel
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DeselectedNodePolicy DeselectedNodePolicy `json:"deselectedNodePolicy,omitempty"`
+
+	// HealthyStabilizationWindow is the duration for which a node under remediation needs to stay healthy,
+	// before its remediation is stopped by deleting the remediation CR. This prevents restarting remediation
+	// for nodes which only look healthy for a short time.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	HealthyStabilizationWindow *metav1.Duration `json:"healthyStabilizationWindow,omitempty"`
+
+	// FlappingDetection optionally configures detection of nodes which toggle between unhealthy and healthy,
+	// and how to handle them.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	FlappingDetection *FlappingDetection `json:"flappingDetection,omitempty"`
+
+	// RemediationRateLimit optionally limits the number of new remediations which are started within a sliding
+	// time window. Unhealthy nodes exceeding the limit stay queued until the oldest remediation leaves the window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationRateLimit *RemediationRateLimit `json:"remediationRateLimit,omitempty"`
+
+	// StormDetection optionally pauses remediation automatically, when many nodes become unhealthy within a short
+	// time, e.g. because of a network problem. Remediation resumes when no node became unhealthy for a while.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StormDetection *StormDetection `json:"stormDetection,omitempty"`
+}
+
+// NodeDeletionPolicy is the string used for NHC.Spec.NodeDeletionPolicy
+type NodeDeletionPolicy string
+
+const (
+	// NodeDeletionPolicyDelete deletes the remediation CRs of deleted nodes
+	NodeDeletionPolicyDelete NodeDeletionPolicy = "Delete"
+
+	// NodeDeletionPolicyOrphan removes the NHC owner reference from the remediation CRs of deleted nodes
+	NodeDeletionPolicyOrphan NodeDeletionPolicy = "Orphan"
+)
+
+// DeselectedNodePolicy is the string used for NHC.Spec.DeselectedNodePolicy
+type DeselectedNodePolicy string
+
+const (
+	// DeselectedNodePolicyFinish keeps remediating deselected nodes until they are healthy or their remediation timed out
+	DeselectedNodePolicyFinish DeselectedNodePolicy = "Finish"
+
+	// DeselectedNodePolicyCancel deletes the remediation CRs of deselected nodes
+	DeselectedNodePolicyCancel DeselectedNodePolicy = "Cancel"
+)
+
+// FlappingDetection defines when a node is flapping, and what happens with flapping nodes
+type FlappingDetection struct {
+	// MaxTransitions is the number of times a node under remediation may become healthy within the window.
+	// When it becomes healthy more often, it's flapping.
+	//
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxTransitions int `json:"maxTransitions"`
+
+	// Window is the duration in which transitions to healthy are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+
+	// Action defines what happens with flapping nodes.
+	// "StopRemediation" doesn't start new remediations for the node while it's flapping.
+	// "Escalate" keeps the node's remediation running while it's flapping, and immediately escalates it to the
+	// next escalating remediation. It can only be used with EscalatingRemediations.
+	//
+	//+optional
+	//+kubebuilder:default=StopRemediation
+	//+kubebuilder:validation:Enum=StopRemediation;Escalate
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Action FlappingAction `json:"action,omitempty"`
+}
+
+// StormDetection defines when many nodes becoming unhealthy are a storm, and when the storm is over
+type StormDetection struct {
+	// MaxNewUnhealthyNodes is the number of selected nodes which may become unhealthy within the window.
+	// When more nodes become unhealthy, a storm is detected and remediation is paused.
+	// Expects either a positive integer value or a percentage value.
+	// Percentage values must be positive whole numbers and are capped at 100%.
+	//
+	//+kubebuilder:validation:XIntOrString
+	//+kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxNewUnhealthyNodes intstr.IntOrString `json:"maxNewUnhealthyNodes"`
+
+	// Window is the duration in which nodes becoming unhealthy are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+
+	// StabilizationPeriod is the duration in which no node may become unhealthy, before remediation is resumed
+	// after a storm.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	StabilizationPeriod metav1.Duration `json:"stabilizationPeriod"`
+}
+
+// Topology defines the failure domains of the selected nodes
+type Topology struct {
+	// Key is the key of the node label, whose values define the failure domains,
+	// e.g. "topology.kubernetes.io/zone". Nodes without this label don't belong to any failure domain.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// MaxRemediationsPerDomain optionally limits the number of nodes which are remediated concurrently within each
+	// failure domain. New remediations are only started while less nodes of the domain are under remediation.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRemediationsPerDomain *int `json:"maxRemediationsPerDomain,omitempty"`
+}
+
+// RemediationRateLimit defines how many new remediations can be started within a sliding time window
+type RemediationRateLimit struct {
+	// MaxRemediations is the maximum number of new remediations which are started within the window.
+	//
+	//+kubebuilder:validation:Minimum=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxRemediations int `json:"maxRemediations"`
+
+	// Window is the duration in which new remediations are counted.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+}
+
+// FlappingAction is the string used for FlappingDetection.Action
+type FlappingAction string
+
+const (
+	// FlappingActionStopRemediation doesn't start new remediations for flapping nodes
+	FlappingActionStopRemediation FlappingAction = "StopRemediation"
+
+	// FlappingActionEscalate escalates the remediation of flapping nodes to the next escalating remediation
+	FlappingActionEscalate FlappingAction = "Escalate"
+)
+
+// DeletionPolicy is the string used for NHC.Spec.DeletionPolicy
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyWait waits for ongoing remediations to finish before the NHC is deleted
+	DeletionPolicyWait DeletionPolicy = "Wait"
+
+	// DeletionPolicyCancel deletes the remediation CRs of ongoing remediations
+	DeletionPolicyCancel DeletionPolicy = "Cancel"
+
+	// DeletionPolicyOrphan removes the NHC owner reference from the remediation CRs of ongoing remediations
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// UnhealthyCondition represents a Node condition type and value with a
+// specified duration. When the named condition has been in the given
+// status for at least the duration value a node is considered unhealthy.
+type UnhealthyCondition struct {
+	// The condition type in the node's status to watch for.
+	//
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Type corev1.NodeConditionType `json:"type"`
+
+	// The condition status in the node's status to watch for.
+	// Typically False, True or Unknown.
+	//
+	//+kubebuilder:validation:Type=string
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Duration of the condition specified when a node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+
+	// AbsentPolicy defines how to treat nodes which don't have a condition of the given type.
+	// "Ignore" skips this condition for these nodes, "Unhealthy" considers these nodes as unhealthy
+	// after the condition is absent for AbsentDuration.
+	// This is useful for custom conditions, e.g. from node-problem-detector, where a missing condition
+	// indicates that the detector doesn't work.
+	//
+	//+optional
+	//+kubebuilder:default=Ignore
+	//+kubebuilder:validation:Enum=Ignore;Unhealthy
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AbsentPolicy AbsentConditionPolicy `json:"absentPolicy,omitempty"`
+
+	// AbsentDuration is the duration for which the condition needs to be absent, before the node is considered
+	// unhealthy. Only used with AbsentPolicy "Unhealthy". Defaults to Duration.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	AbsentDuration *metav1.Duration `json:"absentDuration,omitempty"`
+}
+
+// UnhealthyPod defines pods, which make the node they are running on unhealthy when they aren't ready
+// for the given duration. At least one of Selector or DaemonSet needs to be set.
+type UnhealthyPod struct {
+	// Namespace of the pods.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+
+	// Selector is a label selector for the pods.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// DaemonSet is the name of the DaemonSet owning the pods.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	DaemonSet string `json:"daemonSet,omitempty"`
+
+	// Duration for which the pod needs to be not ready, before its node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthyTaint defines a taint, which makes a node unhealthy when it exists for the given duration.
+type UnhealthyTaint struct {
+	// Key of the taint.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+
+	// Value of the taint. When empty, taints with any value match.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value,omitempty"`
+
+	// Effect of the taint.
+	//
+	//+kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Effect corev1.TaintEffect `json:"effect"`
+
+	// Duration for which the taint needs to exist, before the node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+// UnhealthySignal defines NodeHealthSignals, which make a node unhealthy.
+type UnhealthySignal struct {
+	// Source of the NodeHealthSignals.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Source string `json:"source"`
+
+	// MinSeverity is the minimum severity of NodeHealthSignals, which make a node unhealthy.
+	// One of "Info", "Warning" or "Critical".
+	//
+	//+optional
+	//+kubebuilder:default=Critical
+	//+kubebuilder:validation:Enum=Info;Warning;Critical
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	MinSeverity SignalSeverity `json:"minSeverity,omitempty"`
+}
+
+// SignalSeverity is the severity of a NodeHealthSignal
+type SignalSeverity string
+
+const (
+	// SignalSeverityInfo is used for signals which don't indicate a problem
+	SignalSeverityInfo SignalSeverity = "Info"
+	// SignalSeverityWarning is used for signals which indicate a degraded node
+	SignalSeverityWarning SignalSeverity = "Warning"
+	// SignalSeverityCritical is used for signals which indicate a failed node
+	SignalSeverityCritical SignalSeverity = "Critical"
+)
+
+// PrometheusQuery defines a PromQL query, whose results identify unhealthy nodes.
+// Each returned series is mapped to a node by a label, and the node is unhealthy when the series' value
+// breaches the threshold for the given duration.
+type PrometheusQuery struct {
+	// URL of the Prometheus compatible HTTP API, e.g. "https://thanos-querier.openshift-monitoring.svc:9091".
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	URL string `json:"url"`
+
+	// Query is the PromQL query, which needs to return an instant vector.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Query string `json:"query"`
+
+	// NodeLabel is the label of the returned series, which contains the node name.
+	//
+	//+optional
+	//+kubebuilder:default=node
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// Operator is used for comparing the series' value with the threshold. The node is unhealthy when the
+	// comparison is true. One of "GreaterThan", "GreaterThanOrEqual", "LessThan" or "LessThanOrEqual".
+	//
+	//+optional
+	//+kubebuilder:default=GreaterThan
+	//+kubebuilder:validation:Enum=GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Operator ThresholdOperator `json:"operator,omitempty"`
+
+	// Threshold is the decimal number the series' value is compared with.
+	//
+	//+kubebuilder:validation:Pattern="^-?[0-9]+(\\.[0-9]+)?$"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Threshold string `json:"threshold"`
+
+	// Duration for which the threshold needs to be breached, before the node is considered unhealthy.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+
+	// Interval defines how often the query is run. Query results are cached for this interval.
+	//
+	//+optional
+	//+kubebuilder:default:="30s"
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// InsecureSkipTLSVerify disables verification of the server's certificate.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// UseServiceAccountToken configures the operator to authenticate with its service account token.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	UseServiceAccountToken bool `json:"useServiceAccountToken,omitempty"`
+}
+
+// KubeletProbe defines how the kubelet of a node is probed. The probe connects to the node's InternalIP.
+type KubeletProbe struct {
+	// Port of the kubelet's health endpoint. Defaults to the kubelet port reported in the node's status.
+	//
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Port *int32 `json:"port,omitempty"`
+
+	// Path of the kubelet's health endpoint.
+	//
+	//+optional
+	//+kubebuilder:default="/healthz"
+	//+kubebuilder:validation:Pattern="^/"
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Path string `json:"path,omitempty"`
+
+	// Scheme used for connecting to the kubelet, one of "HTTP" or "HTTPS".
+	// The kubelet's serving certificate isn't verified.
+	//
+	//+optional
+	//+kubebuilder:default=HTTPS
+	//+kubebuilder:validation:Enum=HTTP;HTTPS
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Timeout of the probe.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+optional
+	//+kubebuilder:default:="5s"
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ThresholdOperator is the string used for PrometheusQuery.Operator
+type ThresholdOperator string
+
+const (
+	// ThresholdOperatorGreaterThan considers values greater than the threshold as unhealthy
+	ThresholdOperatorGreaterThan ThresholdOperator = "GreaterThan"
+	// ThresholdOperatorGreaterThanOrEqual considers values greater than or equal to the threshold as unhealthy
+	ThresholdOperatorGreaterThanOrEqual ThresholdOperator = "GreaterThanOrEqual"
+	// ThresholdOperatorLessThan considers values less than the threshold as unhealthy
+	ThresholdOperatorLessThan ThresholdOperator = "LessThan"
+	// ThresholdOperatorLessThanOrEqual considers values less than or equal to the threshold as unhealthy
+	ThresholdOperatorLessThanOrEqual ThresholdOperator = "LessThanOrEqual"
+)
+
+// AbsentConditionPolicy is the string used for UnhealthyCondition.AbsentPolicy
+type AbsentConditionPolicy string
+
+const (
+	// AbsentConditionPolicyIgnore skips absent conditions
+	AbsentConditionPolicyIgnore AbsentConditionPolicy = "Ignore"
+
+	// AbsentConditionPolicyUnhealthy considers nodes with absent conditions as unhealthy
+	AbsentConditionPolicyUnhealthy AbsentConditionPolicy = "Unhealthy"
+)
+
+// EscalatingRemediation defines a remediation template with order and timeout
+type EscalatingRemediation struct {
+	// RemediationTemplate is a reference to a remediation template
+	// provided by a remediation provider.
+	//
+	// If a node needs remediation the controller will create an object from this template
+	// and then it should be picked up by a remediation provider.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	RemediationTemplate corev1.ObjectReference `json:"remediationTemplate"`
+
+	// Order defines the order for this remediation.
+	// Remediations with lower order will be used before remediations with higher order.
+	// Remediations must not have the same order.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Order int `json:"order"`
+
+	// Timeout defines how long NHC will wait for the node getting healthy
+	// before the next remediation (if any) will be used. When the last remediation times out,
+	// the overall remediation is considered as failed.
+	// As a safeguard for preventing parallel remediations, a minimum of 60s is enforced.
+	//
+	// Expects a string of decimal numbers each with optional
+	// fraction and a unit suffix, eg "300ms", "1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	//
+	//+kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	//+kubebuilder:validation:Type=string
+	//+operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// NodeHealthCheckStatus defines the observed state of NodeHealthCheck
+type NodeHealthCheckStatus struct {
+	// ObservedNodes specified the number of nodes observed by using the NHC spec.selector
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedNodes int `json:"observedNodes,omitempty"`
+
+	// HealthyNodes specified the number of healthy nodes observed
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyNodes int `json:"healthyNodes,omitempty"`
+
+	// UnhealthyNodes tracks currently unhealthy nodes and their remediations.
+	//
+	//+listType=map
+	//+listMapKey=name
+	//+patchStrategy=merge
+	//+patchMergeKey=name
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyNodes []*UnhealthyNode `json:"unhealthyNodes,omitempty"`
+
+	// ExcludedNodes lists the selected nodes which are excluded from remediation by an annotation.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ExcludedNodes []ExcludedNode `json:"excludedNodes,omitempty"`
+
+	// FailureDomains reports the remediation budget of each failure domain, when Topology is configured.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// Storm tracks since when nodes are unhealthy for the StormDetection, and the detected storm.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Storm *StormStatus `json:"storm,omitempty"`
+
+	// RemediationRateLimit tracks the remediations counted by the RemediationRateLimit, and the nodes which are
+	// queued because the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediationRateLimit *RemediationRateLimitStatus `json:"remediationRateLimit,omitempty"`
+
+	// Represents the observations of a NodeHealthCheck's current state.
+	// Known .status.conditions.type are: "Disabled"
+	//
+	//+patchStrategy=merge
+	//+patchMergeKey=type
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase represents the current phase of this Config.
+	// Known phases are Terminating, Disabled, Paused, Remediating and Enabled, based on:\n
+	// - the deletion timestamp\n
+	// - the status of the Disabled condition\n
+	// - the value of PauseRequests\n
+	// - the value of UnhealthyNodes
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase"
+	Phase NHCPhase `json:"phase,omitempty"`
+
+	// Reason explains the current phase in more detail.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.phase:reason"
+	Reason string `json:"reason,omitempty"`
+}
+
+// UnhealthyNode defines an unhealthy node and its remediations
+type UnhealthyNode struct {
+	// Name is the name of the unhealthy node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// UID is the UID of the unhealthy node. It's used for detecting nodes which were replaced by a new node
+	// with the same name.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UID types.UID `json:"uid,omitempty"`
+
+	// Remediations tracks the remediations created for this node
+	//
+	//+listType=atomic
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Remediations []*Remediation `json:"remediations,omitempty"`
+
+	// KubeletProbe is the result of the last kubelet probe of this node.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	KubeletProbe *KubeletProbeResult `json:"kubeletProbe,omitempty"`
+
+	// HealthySince is the time since when the node looks healthy again. It's only set when HealthyStabilizationWindow
+	// or FlappingDetection is configured.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+
+	// HealthyTransitions are the times within the FlappingDetection window, when the node became healthy again.
+	// The node is kept in the status without remediations as long as it has transitions.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyTransitions []metav1.Time `json:"healthyTransitions,omitempty"`
+
+	// FlappingSince is the time since when the node is flapping.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	FlappingSince *metav1.Time `json:"flappingSince,omitempty"`
+}
+
+// ExcludedNode defines a node which is excluded from remediation
+type ExcludedNode struct {
+	// Name is the name of the excluded node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Until is the time until which the node is excluded. It isn't set for nodes which are excluded without expiry.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Until *metav1.Time `json:"until,omitempty"`
+}
+
+// StormStatus defines the observed state of the StormDetection
+type StormStatus struct {
+	// UnhealthyNodes are the unhealthy nodes, and since when they are unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	UnhealthyNodes []StormUnhealthyNode `json:"unhealthyNodes,omitempty"`
+
+	// LastUnhealthyTransition is the last time a node became unhealthy.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	LastUnhealthyTransition *metav1.Time `json:"lastUnhealthyTransition,omitempty"`
+
+	// DetectedAt is the time when the ongoing storm was detected. It isn't set when there is no storm.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+}
+
+// StormUnhealthyNode defines an unhealthy node tracked by the StormDetection
+type StormUnhealthyNode struct {
+	// Name is the name of the unhealthy node
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Since is the time when the node became unhealthy. It isn't set for nodes which were unhealthy already
+	// when the StormDetection was configured, those aren't counted.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+// FailureDomain defines the observed state of a failure domain
+type FailureDomain struct {
+	// Name is the value of the topology label of the domain's nodes
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// ObservedNodes is the number of selected nodes in the domain
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedNodes int `json:"observedNodes"`
+
+	// HealthyNodes is the number of healthy nodes in the domain
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	HealthyNodes int `json:"healthyNodes"`
+
+	// MinHealthy is the number of healthy nodes in the domain, which is needed for remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	MinHealthy int `json:"minHealthy"`
+
+	// RemediatingNodes is the number of nodes in the domain which are under remediation
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RemediatingNodes int `json:"remediatingNodes"`
+
+	// AvailableRemediations is the number of new remediations which can be started in the domain.
+	// It isn't set when it's not limited.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	AvailableRemediations *int `json:"availableRemediations,omitempty"`
+}
+
+// RemediationRateLimitStatus defines the observed state of the RemediationRateLimit
+type RemediationRateLimitStatus struct {
+	// RecentRemediations are the start times of the new remediations within the window.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	RecentRemediations []metav1.Time `json:"recentRemediations,omitempty"`
+
+	// QueuedNodes are the names of the unhealthy nodes which wait for remediation because the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	QueuedNodes []string `json:"queuedNodes,omitempty"`
+
+	// NextRemediationAt is the time when the next new remediation can be started, if the limit is exceeded.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	NextRemediationAt *metav1.Time `json:"nextRemediationAt,omitempty"`
+}
+
+// KubeletProbeResultType is the string used for KubeletProbeResult.Result
+type KubeletProbeResultType string
+
+const (
+	// KubeletProbeResultResponding is used when the kubelet responded, and remediation is deferred
+	KubeletProbeResultResponding KubeletProbeResultType = "Responding"
+
+	// KubeletProbeResultNotResponding is used when the kubelet didn't respond, which confirms that the node is unhealthy
+	KubeletProbeResultNotResponding KubeletProbeResultType = "NotResponding"
+)
+
+// KubeletProbeResult is the result of probing the kubelet of an unhealthy node
+type KubeletProbeResult struct {
+	// Result is "Responding" when the kubelet responded and the node's remediation is deferred,
+	// or "NotResponding" when the kubelet didn't respond and the node is confirmed to be unhealthy.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Result KubeletProbeResultType `json:"result"`
+
+	// Since is the time of the first probe with this result. Repeated probes with the same result don't
+	// update the status.
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Since metav1.Time `json:"since"`
+
+	// Message contains details about the result
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// Remediation defines a remediation which was created for a node
+type Remediation struct {
+	// Resource is the reference to the remediation CR which was created
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Started is the creation time of the remediation CR
+	//
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	Started metav1.Time `json:"started"`
+
+	// TimedOut is the time when the remediation timed out.
+	// Applicable for escalating remediations only.
+	//
+	//+optional
+	//+operator-sdk:csv:customresourcedefinitions:type=status
+	TimedOut *metav1.Time `json:"timedOut,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=nodehealthchecks,scope=Cluster,shortName=nhc
+//+kubebuilder:subresource:status
+
+// NodeHealthCheck is the Schema for the nodehealthchecks API
+//
+// +operator-sdk:csv:customresourcedefinitions:resources={{"NodeHealthCheck","v1beta1","nodehealthchecks"}}
+type NodeHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeHealthCheckSpec   `json:"spec,omitempty"`
+	Status NodeHealthCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeHealthCheckList contains a list of NodeHealthCheck
+type NodeHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeHealthCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeHealthCheck{}, &NodeHealthCheckList{})
+}
@@ -0,0 +1,663 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EscalatingRemediation) DeepCopyInto(out *EscalatingRemediation) {
+	*out = *in
+	out.RemediationTemplate = in.RemediationTemplate
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EscalatingRemediation.
+func (in *EscalatingRemediation) DeepCopy() *EscalatingRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(EscalatingRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedNode) DeepCopyInto(out *ExcludedNode) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedNode.
+func (in *ExcludedNode) DeepCopy() *ExcludedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomain) DeepCopyInto(out *FailureDomain) {
+	*out = *in
+	if in.AvailableRemediations != nil {
+		in, out := &in.AvailableRemediations, &out.AvailableRemediations
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomain.
+func (in *FailureDomain) DeepCopy() *FailureDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlappingDetection) DeepCopyInto(out *FlappingDetection) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlappingDetection.
+func (in *FlappingDetection) DeepCopy() *FlappingDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(FlappingDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletProbe) DeepCopyInto(out *KubeletProbe) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletProbe.
+func (in *KubeletProbe) DeepCopy() *KubeletProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletProbeResult) DeepCopyInto(out *KubeletProbeResult) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletProbeResult.
+func (in *KubeletProbeResult) DeepCopy() *KubeletProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheck) DeepCopyInto(out *NodeHealthCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheck.
+func (in *NodeHealthCheck) DeepCopy() *NodeHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckList) DeepCopyInto(out *NodeHealthCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckList.
+func (in *NodeHealthCheckList) DeepCopy() *NodeHealthCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckSpec) DeepCopyInto(out *NodeHealthCheckSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]UnhealthyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaleLeaseDuration != nil {
+		in, out := &in.StaleLeaseDuration, &out.StaleLeaseDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyPods != nil {
+		in, out := &in.UnhealthyPods, &out.UnhealthyPods
+		*out = make([]UnhealthyPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnhealthyTaints != nil {
+		in, out := &in.UnhealthyTaints, &out.UnhealthyTaints
+		*out = make([]UnhealthyTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthySignals != nil {
+		in, out := &in.UnhealthySignals, &out.UnhealthySignals
+		*out = make([]UnhealthySignal, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrometheusQuery != nil {
+		in, out := &in.PrometheusQuery, &out.PrometheusQuery
+		*out = new(PrometheusQuery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletProbe != nil {
+		in, out := &in.KubeletProbe, &out.KubeletProbe
+		*out = new(KubeletProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinHealthy != nil {
+		in, out := &in.MinHealthy, &out.MinHealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(Topology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationTemplate != nil {
+		in, out := &in.RemediationTemplate, &out.RemediationTemplate
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.EscalatingRemediations != nil {
+		in, out := &in.EscalatingRemediations, &out.EscalatingRemediations
+		*out = make([]EscalatingRemediation, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseRequests != nil {
+		in, out := &in.PauseRequests, &out.PauseRequests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthyStabilizationWindow != nil {
+		in, out := &in.HealthyStabilizationWindow, &out.HealthyStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FlappingDetection != nil {
+		in, out := &in.FlappingDetection, &out.FlappingDetection
+		*out = new(FlappingDetection)
+		**out = **in
+	}
+	if in.RemediationRateLimit != nil {
+		in, out := &in.RemediationRateLimit, &out.RemediationRateLimit
+		*out = new(RemediationRateLimit)
+		**out = **in
+	}
+	if in.StormDetection != nil {
+		in, out := &in.StormDetection, &out.StormDetection
+		*out = new(StormDetection)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckSpec.
+func (in *NodeHealthCheckSpec) DeepCopy() *NodeHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthCheckStatus) DeepCopyInto(out *NodeHealthCheckStatus) {
+	*out = *in
+	if in.UnhealthyNodes != nil {
+		in, out := &in.UnhealthyNodes, &out.UnhealthyNodes
+		*out = make([]*UnhealthyNode, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(UnhealthyNode)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExcludedNodes != nil {
+		in, out := &in.ExcludedNodes, &out.ExcludedNodes
+		*out = make([]ExcludedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]FailureDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Storm != nil {
+		in, out := &in.Storm, &out.Storm
+		*out = new(StormStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemediationRateLimit != nil {
+		in, out := &in.RemediationRateLimit, &out.RemediationRateLimit
+		*out = new(RemediationRateLimitStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthCheckStatus.
+func (in *NodeHealthCheckStatus) DeepCopy() *NodeHealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusQuery) DeepCopyInto(out *PrometheusQuery) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusQuery.
+func (in *PrometheusQuery) DeepCopy() *PrometheusQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Remediation) DeepCopyInto(out *Remediation) {
+	*out = *in
+	out.Resource = in.Resource
+	in.Started.DeepCopyInto(&out.Started)
+	if in.TimedOut != nil {
+		in, out := &in.TimedOut, &out.TimedOut
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
+func (in *Remediation) DeepCopy() *Remediation {
+	if in == nil {
+		return nil
+	}
+	out := new(Remediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRateLimit) DeepCopyInto(out *RemediationRateLimit) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRateLimit.
+func (in *RemediationRateLimit) DeepCopy() *RemediationRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRateLimitStatus) DeepCopyInto(out *RemediationRateLimitStatus) {
+	*out = *in
+	if in.RecentRemediations != nil {
+		in, out := &in.RecentRemediations, &out.RecentRemediations
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueuedNodes != nil {
+		in, out := &in.QueuedNodes, &out.QueuedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRemediationAt != nil {
+		in, out := &in.NextRemediationAt, &out.NextRemediationAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRateLimitStatus.
+func (in *RemediationRateLimitStatus) DeepCopy() *RemediationRateLimitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRateLimitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormDetection) DeepCopyInto(out *StormDetection) {
+	*out = *in
+	out.MaxNewUnhealthyNodes = in.MaxNewUnhealthyNodes
+	out.Window = in.Window
+	out.StabilizationPeriod = in.StabilizationPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormDetection.
+func (in *StormDetection) DeepCopy() *StormDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(StormDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormStatus) DeepCopyInto(out *StormStatus) {
+	*out = *in
+	if in.UnhealthyNodes != nil {
+		in, out := &in.UnhealthyNodes, &out.UnhealthyNodes
+		*out = make([]StormUnhealthyNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUnhealthyTransition != nil {
+		in, out := &in.LastUnhealthyTransition, &out.LastUnhealthyTransition
+		*out = (*in).DeepCopy()
+	}
+	if in.DetectedAt != nil {
+		in, out := &in.DetectedAt, &out.DetectedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormStatus.
+func (in *StormStatus) DeepCopy() *StormStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StormStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StormUnhealthyNode) DeepCopyInto(out *StormUnhealthyNode) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StormUnhealthyNode.
+func (in *StormUnhealthyNode) DeepCopy() *StormUnhealthyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(StormUnhealthyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Topology) DeepCopyInto(out *Topology) {
+	*out = *in
+	if in.MaxRemediationsPerDomain != nil {
+		in, out := &in.MaxRemediationsPerDomain, &out.MaxRemediationsPerDomain
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topology.
+func (in *Topology) DeepCopy() *Topology {
+	if in == nil {
+		return nil
+	}
+	out := new(Topology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyCondition) DeepCopyInto(out *UnhealthyCondition) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.AbsentDuration != nil {
+		in, out := &in.AbsentDuration, &out.AbsentDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyCondition.
+func (in *UnhealthyCondition) DeepCopy() *UnhealthyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyNode) DeepCopyInto(out *UnhealthyNode) {
+	*out = *in
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]*Remediation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Remediation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.KubeletProbe != nil {
+		in, out := &in.KubeletProbe, &out.KubeletProbe
+		*out = new(KubeletProbeResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthySince != nil {
+		in, out := &in.HealthySince, &out.HealthySince
+		*out = (*in).DeepCopy()
+	}
+	if in.HealthyTransitions != nil {
+		in, out := &in.HealthyTransitions, &out.HealthyTransitions
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FlappingSince != nil {
+		in, out := &in.FlappingSince, &out.FlappingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyNode.
+func (in *UnhealthyNode) DeepCopy() *UnhealthyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyPod) DeepCopyInto(out *UnhealthyPod) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyPod.
+func (in *UnhealthyPod) DeepCopy() *UnhealthyPod {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthySignal) DeepCopyInto(out *UnhealthySignal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthySignal.
+func (in *UnhealthySignal) DeepCopy() *UnhealthySignal {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthySignal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyTaint) DeepCopyInto(out *UnhealthyTaint) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyTaint.
+func (in *UnhealthyTaint) DeepCopy() *UnhealthyTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyTaint)
+	in.DeepCopyInto(out)
+	return out
+}
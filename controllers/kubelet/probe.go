@@ -0,0 +1,103 @@
+package kubelet
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+const (
+	defaultPath    = "/healthz"
+	defaultTimeout = 5 * time.Second
+)
+
+// GetProbeURL returns the URL of the given node's kubelet health endpoint
+func GetProbeURL(node *v1.Node, probe *remediationv1alpha1.KubeletProbe) (string, error) {
+	var ip string
+	for _, address := range node.Status.Addresses {
+		if address.Type == v1.NodeInternalIP {
+			ip = address.Address
+			break
+		}
+	}
+	if ip == "" {
+		return "", fmt.Errorf("node %s has no %s address", node.Name, v1.NodeInternalIP)
+	}
+
+	port := node.Status.DaemonEndpoints.KubeletEndpoint.Port
+	if probe.Port != nil {
+		port = *probe.Port
+	}
+	if port == 0 {
+		return "", fmt.Errorf("node %s has no kubelet port", node.Name)
+	}
+
+	scheme := "https"
+	if probe.Scheme == v1.URISchemeHTTP {
+		scheme = "http"
+	}
+	path := probe.Path
+	if path == "" {
+		path = defaultPath
+	}
+
+	probeURL := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(ip, strconv.Itoa(int(port))),
+		Path:   path,
+	}
+	return probeURL.String(), nil
+}
+
+// GetTimeout returns the configured or default timeout of the given probe
+func GetTimeout(probe *remediationv1alpha1.KubeletProbe) time.Duration {
+	if probe.Timeout == nil {
+		return defaultTimeout
+	}
+	return probe.Timeout.Duration
+}
+
+// Probe calls the given kubelet health endpoint, and returns an error if the kubelet isn't responding.
+// The kubelet's serving certificate isn't verified, because it's often self-signed, and no sensitive data is sent.
+// Unauthorized and Forbidden responses are considered as responding, because they prove that the kubelet is
+// running, without the need of giving the operator access to the kubelet API.
+func Probe(ctx context.Context, probeURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to probe kubelet: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil
+	default:
+		return fmt.Errorf("kubelet responded with HTTP status %d", resp.StatusCode)
+	}
+}
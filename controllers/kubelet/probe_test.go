@@ -0,0 +1,137 @@
+package kubelet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+var _ = Describe("Kubelet probe", func() {
+
+	Context("probe URL", func() {
+		var node *v1.Node
+
+		BeforeEach(func() {
+			node = &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Status: v1.NodeStatus{
+					Addresses: []v1.NodeAddress{
+						{Type: v1.NodeHostName, Address: "node-1"},
+						{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+					},
+					DaemonEndpoints: v1.NodeDaemonEndpoints{
+						KubeletEndpoint: v1.DaemonEndpoint{Port: 10250},
+					},
+				},
+			}
+		})
+
+		It("uses the kubelet endpoint by default", func() {
+			probeURL, err := GetProbeURL(node, &remediationv1alpha1.KubeletProbe{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(probeURL).To(Equal("https://10.0.0.1:10250/healthz"))
+		})
+
+		It("uses the configured port, path and scheme", func() {
+			probeURL, err := GetProbeURL(node, &remediationv1alpha1.KubeletProbe{
+				Port:   pointer.Int32(8080),
+				Path:   "/livez",
+				Scheme: v1.URISchemeHTTP,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(probeURL).To(Equal("http://10.0.0.1:8080/livez"))
+		})
+
+		It("supports IPv6 addresses", func() {
+			node.Status.Addresses[1].Address = "fd00::1"
+			probeURL, err := GetProbeURL(node, &remediationv1alpha1.KubeletProbe{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(probeURL).To(Equal("https://[fd00::1]:10250/healthz"))
+		})
+
+		It("fails without internal IP", func() {
+			node.Status.Addresses = node.Status.Addresses[:1]
+			_, err := GetProbeURL(node, &remediationv1alpha1.KubeletProbe{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("probing", func() {
+		var (
+			statusCode int
+			delay      time.Duration
+			server     *httptest.Server
+			probeErr   error
+		)
+
+		BeforeEach(func() {
+			statusCode = http.StatusOK
+			delay = 0
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(delay)
+				w.WriteHeader(statusCode)
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		JustBeforeEach(func() {
+			probeErr = Probe(context.Background(), server.URL+"/healthz", 500*time.Millisecond)
+		})
+
+		When("the kubelet is healthy", func() {
+			It("succeeds", func() {
+				Expect(probeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		When("the kubelet requires authentication", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusUnauthorized
+			})
+
+			It("succeeds", func() {
+				Expect(probeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		When("the kubelet is unhealthy", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusInternalServerError
+			})
+
+			It("fails", func() {
+				Expect(probeErr).To(MatchError(ContainSubstring("500")))
+			})
+		})
+
+		When("the kubelet doesn't respond in time", func() {
+			BeforeEach(func() {
+				delay = time.Second
+			})
+
+			It("fails", func() {
+				Expect(probeErr).To(HaveOccurred())
+			})
+		})
+
+		When("the kubelet isn't reachable", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+
+			It("fails", func() {
+				Expect(probeErr).To(HaveOccurred())
+			})
+		})
+	})
+})
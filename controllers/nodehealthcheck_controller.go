@@ -19,19 +19,25 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
 	"github.com/pkg/errors"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
@@ -40,6 +46,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -47,10 +54,13 @@ import (
 
 	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
 	"github.com/medik8s/node-healthcheck-operator/controllers/cluster"
+	"github.com/medik8s/node-healthcheck-operator/controllers/kubelet"
 	"github.com/medik8s/node-healthcheck-operator/controllers/mhc"
+	"github.com/medik8s/node-healthcheck-operator/controllers/prometheus"
 	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 	"github.com/medik8s/node-healthcheck-operator/metrics"
+	"github.com/medik8s/node-healthcheck-operator/pkg/expression"
 )
 
 const (
@@ -61,20 +71,39 @@ const (
 	eventReasonRemediationCreated    = "RemediationCreated"
 	eventReasonRemediationSkipped    = "RemediationSkipped"
 	eventReasonRemediationRemoved    = "RemediationRemoved"
+	eventReasonRemediationOrphaned   = "RemediationOrphaned"
 	eventReasonNoTemplateLeft        = "NoTemplateLeft"
 	eventReasonDisabled              = "Disabled"
 	eventReasonEnabled               = "Enabled"
+	eventReasonSelectorOverlap       = "SelectorOverlap"
+	eventReasonPrometheusQueryFailed = "PrometheusQueryFailed"
+	eventReasonRemediationDeferred   = "RemediationDeferred"
+	eventReasonNodeFlapping          = "NodeFlapping"
+	eventReasonRemediationCompleted  = "RemediationCompleted"
+	eventReasonRemediationCancelled  = "RemediationCancelled"
+	eventReasonNodeExcluded          = "NodeExcluded"
+	eventReasonNodeOverride          = "NodeOverride"
+	eventReasonStormDetected         = "StormDetected"
+	eventReasonStormEnded            = "StormEnded"
 	eventTypeNormal                  = "Normal"
 	eventTypeWarning                 = "Warning"
 	enabledMessage                   = "No issues found, NodeHealthCheck is enabled."
 	conditionTypeProcessing          = "Processing"
+	nhcFinalizer                     = "remediation.medik8s.io/nhc-deletion"
+	defaultPrometheusNodeLabel       = "node"
+	defaultPrometheusInterval        = 30 * time.Second
 
 	// RemediationControlPlaneLabelKey is the label key to put on remediation CRs for control plane nodes
 	RemediationControlPlaneLabelKey = "remediation.medik8s.io/isControlPlaneNode"
 )
 
 var (
+	// errRemediationRateLimitExceeded is returned when the RemediationRateLimit doesn't allow creating a remediation CR
+	errRemediationRateLimitExceeded = errors.New("remediation rate limit exceeded")
+
 	clusterUpgradeRequeueAfter = 1 * time.Minute
+	terminatingRequeueAfter    = 15 * time.Second
+	kubeletProbeRequeueAfter   = 30 * time.Second
 	currentTime                = func() time.Time { return time.Now() }
 )
 
@@ -90,6 +119,9 @@ type NodeHealthCheckReconciler struct {
 	ctrl                        controller.Controller
 	watches                     map[string]struct{}
 	watchesLock                 sync.Mutex
+	firstSeen                   map[string]time.Time
+	firstSeenLock               sync.Mutex
+	prometheusClient            *prometheus.Client
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -103,13 +135,70 @@ func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				predicate.Funcs{
 					// check for modified conditions on updates in order to prevent unneeded reconciliations
 					UpdateFunc: func(ev event.UpdateEvent) bool { return nodeUpdateNeedsReconcile(ev) },
-					// create (new nodes don't have correct conditions yet), delete and generic events are not interesting for now
+					// new nodes don't have correct conditions yet, but they need to be checked for the node startup timeout
+					CreateFunc: func(_ event.CreateEvent) bool { return true },
+					// deleted nodes need to be cleaned up from status and their remediation CRs
+					DeleteFunc: func(_ event.DeleteEvent) bool { return true },
+					// generic events are not interesting for now
+					GenericFunc: func(_ event.GenericEvent) bool { return false },
+				},
+			),
+		).
+		Watches(
+			&source.Kind{Type: &remediationv1alpha1.NodeHealthCheck{}},
+			handler.EnqueueRequestsFromMapFunc(utils.NHCByOtherNHCMapperFunc(mgr.GetClient(), mgr.GetLogger())),
+			builder.WithPredicates(
+				predicate.Funcs{
+					// other NHCs need to update their SelectorOverlap condition when selectors change
+					UpdateFunc:  func(ev event.UpdateEvent) bool { return nhcSelectorChanged(ev) },
+					CreateFunc:  func(_ event.CreateEvent) bool { return true },
+					DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+					GenericFunc: func(_ event.GenericEvent) bool { return false },
+				},
+			),
+		).
+		Watches(
+			&source.Kind{Type: &remediationv1alpha1.NodeHealthSignal{}},
+			handler.EnqueueRequestsFromMapFunc(utils.NHCByNodeHealthSignalMapperFunc(mgr.GetClient(), mgr.GetLogger())),
+			builder.WithPredicates(
+				predicate.Funcs{
+					// signals are created and updated by health agents, all of these are interesting
+					UpdateFunc:  func(_ event.UpdateEvent) bool { return true },
+					CreateFunc:  func(_ event.CreateEvent) bool { return true },
+					DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+					GenericFunc: func(_ event.GenericEvent) bool { return false },
+				},
+			),
+		).
+		Watches(
+			// node leases are named like their node, so the node mapper works for them as well
+			&source.Kind{Type: &coordinationv1.Lease{}},
+			handler.EnqueueRequestsFromMapFunc(utils.NHCByNodeMapperFunc(mgr.GetClient(), mgr.GetLogger())),
+			builder.WithPredicates(
+				// other leases might be named like a node as well
+				predicate.NewPredicateFuncs(isNodeLease),
+				predicate.Funcs{
+					// leases becoming stale are handled by requeuing, only renewals of stale leases are interesting
+					UpdateFunc:  func(ev event.UpdateEvent) bool { return leaseUpdateNeedsReconcile(ev) },
 					CreateFunc:  func(_ event.CreateEvent) bool { return false },
 					DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
 					GenericFunc: func(_ event.GenericEvent) bool { return false },
 				},
 			),
 		).
+		Watches(
+			&source.Kind{Type: &remediationv1alpha1.RemediationBudget{}},
+			handler.EnqueueRequestsFromMapFunc(utils.NHCByRemediationBudgetMapperFunc(mgr.GetClient(), mgr.GetLogger())),
+			builder.WithPredicates(
+				predicate.Funcs{
+					// NHCs with postponed remediations are only interested in more budget
+					UpdateFunc:  func(ev event.UpdateEvent) bool { return budgetUpdateNeedsReconcile(ev) },
+					CreateFunc:  func(_ event.CreateEvent) bool { return true },
+					DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+					GenericFunc: func(_ event.GenericEvent) bool { return false },
+				},
+			),
+		).
 		Build(r)
 
 	if err != nil {
@@ -117,9 +206,52 @@ func (r *NodeHealthCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	r.ctrl = ctrl
 	r.watches = make(map[string]struct{})
+	r.firstSeen = make(map[string]time.Time)
+	r.prometheusClient = prometheus.NewClient()
 	return nil
 }
 
+// podUpdateNeedsReconcile returns true for pods whose readiness changed
+func podUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	var oldPod *v1.Pod
+	var newPod *v1.Pod
+	var ok bool
+	if oldPod, ok = ev.ObjectOld.(*v1.Pod); !ok {
+		return false
+	}
+	if newPod, ok = ev.ObjectNew.(*v1.Pod); !ok {
+		return false
+	}
+	return utils.IsPodReady(oldPod) != utils.IsPodReady(newPod)
+}
+
+// isNodeLease returns true for the leases which kubelets use for their heartbeats
+func isNodeLease(o client.Object) bool {
+	return o.GetNamespace() == v1.NamespaceNodeLease
+}
+
+// leaseUpdateNeedsReconcile returns true for node leases which are renewed after they weren't renewed for longer
+// than their lease duration
+func leaseUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	var oldLease *coordinationv1.Lease
+	var newLease *coordinationv1.Lease
+	var ok bool
+	if oldLease, ok = ev.ObjectOld.(*coordinationv1.Lease); !ok {
+		return false
+	}
+	if newLease, ok = ev.ObjectNew.(*coordinationv1.Lease); !ok {
+		return false
+	}
+	if newLease.Spec.RenewTime == nil || newLease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	if oldLease.Spec.RenewTime == nil {
+		return true
+	}
+	leaseDuration := time.Duration(*newLease.Spec.LeaseDurationSeconds) * time.Second
+	return newLease.Spec.RenewTime.Sub(oldLease.Spec.RenewTime.Time) > leaseDuration
+}
+
 func nodeUpdateNeedsReconcile(ev event.UpdateEvent) bool {
 	var oldNode *v1.Node
 	var newNode *v1.Node
@@ -130,7 +262,71 @@ func nodeUpdateNeedsReconcile(ev event.UpdateEvent) bool {
 	if newNode, ok = ev.ObjectNew.(*v1.Node); !ok {
 		return false
 	}
-	return conditionsNeedReconcile(oldNode.Status.Conditions, newNode.Status.Conditions)
+	// label changes might (de)select the node
+	return conditionsNeedReconcile(oldNode.Status.Conditions, newNode.Status.Conditions) ||
+		taintsNeedReconcile(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+		!reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		annotationsNeedReconcile(oldNode.Annotations, newNode.Annotations)
+}
+
+// annotationsNeedReconcile returns true when the exclude or override annotations changed
+func annotationsNeedReconcile(oldAnnotations, newAnnotations map[string]string) bool {
+	for _, key := range []string{remediationv1alpha1.NodeExcludeAnnotationKey, remediationv1alpha1.NodeOverrideAnnotationKey} {
+		oldValue, oldExists := oldAnnotations[key]
+		newValue, newExists := newAnnotations[key]
+		if oldExists != newExists || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
+
+func taintsNeedReconcile(oldTaints, newTaints []v1.Taint) bool {
+	if len(oldTaints) != len(newTaints) {
+		return true
+	}
+	for _, taintOld := range oldTaints {
+		taintFound := false
+		for _, taintNew := range newTaints {
+			if taintOld.MatchTaint(&taintNew) {
+				if taintOld.Value != taintNew.Value {
+					return true
+				}
+				taintFound = true
+			}
+		}
+		if !taintFound {
+			return true
+		}
+	}
+	return false
+}
+
+// budgetUpdateNeedsReconcile returns true when the RemediationBudget's limits changed, or when remediations released it
+func budgetUpdateNeedsReconcile(ev event.UpdateEvent) bool {
+	var oldBudget *remediationv1alpha1.RemediationBudget
+	var newBudget *remediationv1alpha1.RemediationBudget
+	var ok bool
+	if oldBudget, ok = ev.ObjectOld.(*remediationv1alpha1.RemediationBudget); !ok {
+		return false
+	}
+	if newBudget, ok = ev.ObjectNew.(*remediationv1alpha1.RemediationBudget); !ok {
+		return false
+	}
+	return oldBudget.Generation != newBudget.Generation || len(newBudget.Status.Remediations) < len(oldBudget.Status.Remediations)
+}
+
+func nhcSelectorChanged(ev event.UpdateEvent) bool {
+	var oldNHC *remediationv1alpha1.NodeHealthCheck
+	var newNHC *remediationv1alpha1.NodeHealthCheck
+	var ok bool
+	if oldNHC, ok = ev.ObjectOld.(*remediationv1alpha1.NodeHealthCheck); !ok {
+		return false
+	}
+	if newNHC, ok = ev.ObjectNew.(*remediationv1alpha1.NodeHealthCheck); !ok {
+		return false
+	}
+	return !reflect.DeepEqual(oldNHC.Spec.Selector, newNHC.Spec.Selector)
 }
 
 func conditionsNeedReconcile(oldConditions, newConditions []v1.NodeCondition) bool {
@@ -168,10 +364,15 @@ func conditionsNeedReconcile(oldConditions, newConditions []v1.NodeCondition) bo
 	return false
 }
 
-// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthchecks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=remediation.medik8s.io,resources=nodehealthsignals,verbs=get;list;watch
+// +kubebuilder:rbac:groups=remediation.medik8s.io,resources=remediationbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=remediation.medik8s.io,resources=remediationbudgets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=machine.openshift.io,resources=machines,verbs=get;list;watch
 // +kubebuilder:rbac:groups=machine.openshift.io,resources=machinehealthchecks,verbs=get;list;watch
@@ -193,11 +394,25 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, err
 	}
 
+	// add finalizer, in order to handle ongoing remediations on deletion
+	if nhc.DeletionTimestamp == nil && !controllerutil.ContainsFinalizer(nhc, nhcFinalizer) {
+		controllerutil.AddFinalizer(nhc, nhcFinalizer)
+		if err := r.Update(ctx, nhc); err != nil {
+			return result, errors.Wrapf(err, "failed to add finalizer")
+		}
+		// the update triggers a new reconcile
+		return result, nil
+	}
+
 	resourceManager := resources.NewManager(r.Client, ctx, r.Log, r.OnOpenShift)
 
 	// always check if we need to patch status before we exit Reconcile
 	nhcOrig := nhc.DeepCopy()
 	defer func() {
+		if nhc.DeletionTimestamp != nil && !controllerutil.ContainsFinalizer(nhc, nhcFinalizer) {
+			// the NHC is gone
+			return
+		}
 		patchErr := r.patchStatus(nhc, nhcOrig)
 		if patchErr != nil {
 			log.Error(err, "failed to update status")
@@ -214,6 +429,11 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	nhc.Status.ObservedNodes = 0
 	nhc.Status.HealthyNodes = 0
 
+	// handle deletion with Cancel or Orphan policy, Wait is handled below
+	if nhc.DeletionTimestamp != nil && getDeletionPolicy(nhc) != remediationv1alpha1.DeletionPolicyWait {
+		return result, r.handleDeletion(ctx, nhc, resourceManager)
+	}
+
 	// check if we need to disable NHC because of existing MHCs
 	if disable := r.MHCChecker.NeedDisableNHC(); disable {
 		// update status if needed
@@ -227,6 +447,10 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			})
 			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonDisabled, "Custom MachineHealthCheck(s) detected, disabling NodeHealthCheck to avoid conflicts")
 		}
+		// disabled NHCs don't process remediations, so there is nothing to wait for on deletion
+		if nhc.DeletionTimestamp != nil {
+			return result, r.removeFinalizer(ctx, nhc, resourceManager)
+		}
 		// stop reconciling
 		return result, nil
 	}
@@ -246,6 +470,9 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			})
 			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonDisabled, "Disabling NHC. Reason: %s, Message: %s", reason, message)
 		}
+		if nhc.DeletionTimestamp != nil {
+			return result, r.removeFinalizer(ctx, nhc, resourceManager)
+		}
 		if reason == remediationv1alpha1.ConditionReasonDisabledTemplateNotFound {
 			// requeue for checking back if template exists later
 			result.RequeueAfter = 15 * time.Second
@@ -272,10 +499,76 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 	nhc.Status.ObservedNodes = len(nodes)
 
+	// check if other NHCs select the same nodes
+	if err := r.updateSelectorOverlapCondition(nhc, nodes, resourceManager); err != nil {
+		return result, err
+	}
+
+	// get additional health signals if needed
+	signals, err := r.getHealthSignals(ctx, nhc, resourceManager)
+	if err != nil {
+		return result, err
+	}
+
+	r.updateExcludedNodes(nhc, nodes)
+
+	// close out remediations of nodes which were deleted, or replaced by a new node with the same name. This cleans up
+	// state of nodes which are gone, so it's done while remediation is paused as well, and before the health check,
+	// so that new nodes are checked from scratch
+	replacedNodes, err := r.handleReplacedNodes(nhc, nodes, resourceManager)
+	if err != nil {
+		return result, err
+	}
+	if err := r.handleDeletedNodes(ctx, nhc, nodes, resourceManager); err != nil {
+		return result, err
+	}
+
 	// check nodes health
-	healthyNodes, unhealthyNodes := r.checkNodesHealth(nodes, nhc)
+	healthyNodes, unhealthyNodes, nextHealthCheck := r.checkNodesHealth(nodes, signals, nhc)
 	nhc.Status.HealthyNodes = len(healthyNodes)
 
+	// track nodes which look healthy again, for stabilization and flapping detection
+	nextTransitionExpiry := r.updateHealthTransitions(nhc, healthyNodes, unhealthyNodes)
+
+	// forget remediations which left the rate limit window
+	updateRemediationRateLimit(nhc)
+
+	// detect storms of nodes becoming unhealthy
+	nextStormCheck, err := r.updateStorm(nhc, nodes, unhealthyNodes)
+	if err != nil {
+		return result, err
+	}
+
+	// with Wait policy, deletion can be finished when there are no ongoing remediations anymore, including the ones of
+	// nodes which look healthy within the healthy stabilization window, or which aren't selected anymore
+	if nhc.DeletionTimestamp != nil {
+		if countRemediatingNodes(nhc) == 0 {
+			log.Info("no ongoing remediations left, finishing deletion")
+			return result, r.removeFinalizer(ctx, nhc, resourceManager)
+		}
+		// remediated nodes might be deleted instead of getting healthy, check back regularly
+		result.RequeueAfter = terminatingRequeueAfter
+	}
+
+	// durations of unhealthy state don't trigger events, so check back when they might make a node unhealthy
+	if nextHealthCheck != nil {
+		updateResultNextReconcile(&result, *nextHealthCheck)
+	}
+	// flapping nodes need to be cleaned up when their transitions leave the flapping detection window
+	if nextTransitionExpiry != nil {
+		updateResultNextReconcile(&result, *nextTransitionExpiry)
+	}
+	// storms end without further events, when enough time passed
+	if nextStormCheck != nil {
+		updateResultNextReconcile(&result, *nextStormCheck)
+	}
+
+	// release the cluster-wide remediation budget of finished remediations, also while remediation is paused
+	if err := resourceManager.SyncRemediationBudget(nhc, resources.GetStatusTemplateKinds(nhc)); err != nil {
+		log.Error(err, "failed to sync remediation budget")
+		return result, err
+	}
+
 	// TODO consider setting Disabled condition?
 	if r.isClusterUpgrading() {
 		msg := "Postponing potential remediations because of ongoing cluster upgrade"
@@ -293,8 +586,48 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, nil
 	}
 
+	if isStormDetected(nhc) {
+		// too many nodes became unhealthy at once, remediation might do more harm than good
+		msg := "Postponing potential remediations because of a storm of unhealthy nodes"
+		log.Info(msg)
+		r.Recorder.Event(nhc, eventTypeNormal, eventReasonRemediationSkipped, msg)
+		return result, nil
+	}
+
+	// finish or cancel remediations of nodes which aren't selected anymore
+	nextUnselectedNodesCheck, err := r.handleUnselectedNodes(ctx, nhc, nodes, signals, resourceManager)
+	if err != nil {
+		return result, err
+	}
+	// deselected nodes need to be checked for getting healthy or timing out
+	if nextUnselectedNodesCheck != nil {
+		updateResultNextReconcile(&result, *nextUnselectedNodesCheck)
+	}
+
+	// handle manual overrides
+	overriddenNodes, err := r.handleNodeOverrides(ctx, nhc, nodes, resourceManager)
+	if err != nil {
+		return result, err
+	}
+	if len(overriddenNodes) > 0 {
+		// overridden nodes were checked with the observations which were reset, check them from scratch
+		updateResultNextReconcile(&result, 1*time.Second)
+	}
+
 	// delete remediation CRs for healthy nodes
 	for _, node := range healthyNodes {
+		if _, overridden := overriddenNodes[node.Name]; overridden {
+			continue
+		}
+		if remaining := getHealthyStabilizationRemaining(nhc, node.Name); remaining != nil {
+			// wait until the node is healthy long enough
+			updateResultNextReconcile(&result, *remaining)
+			continue
+		}
+		if isFlappingNode(nhc, node.Name) && getFlappingAction(nhc) == remediationv1alpha1.FlappingActionEscalate && isRemediatingNode(nhc, node.Name) {
+			// don't trust the healthy state of flapping nodes, keep remediating for escalation
+			continue
+		}
 		remediationCRs, err := resourceManager.ListRemediationCRs(nhc, func(cr unstructured.Unstructured) bool {
 			return cr.GetName() == node.GetName()
 		})
@@ -314,14 +647,32 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			// always update status, in case patching it failed during last reconcile
 			resources.UpdateStatusNodeHealthy(&node, nhc)
 		}
+		// nodes with deferred remediation have a status without remediations, clean it up
+		if len(remediationCRs) == 0 && !isRemediatingNode(nhc, node.Name) {
+			resources.UpdateStatusNodeHealthy(&node, nhc)
+		}
+	}
+
+	// release the cluster-wide remediation budget of remediations which finished just now
+	if err := resourceManager.SyncRemediationBudget(nhc, resources.GetStatusTemplateKinds(nhc)); err != nil {
+		log.Error(err, "failed to sync remediation budget")
+		return result, err
+	}
+
+	// count healthy and remediating nodes per failure domain
+	domains, err := updateFailureDomains(nhc, nodes, healthyNodes)
+	if err != nil {
+		log.Error(err, "failed to update failure domains")
+		return result, err
 	}
 
 	// we are done in case we don't have unhealthy nodes
 	if len(unhealthyNodes) == 0 {
+		setRemediationBlockedCondition(nhc, remediationv1alpha1.ConditionReasonRemediationAllowed, "")
 		return result, nil
 	}
 
-	// check if we have enough healthy nodes
+	// check if we have enough healthy nodes, with a topology this is checked per failure domain as well
 	if minHealthy, err := intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MinHealthy, len(nodes), true); err != nil {
 		log.Error(err, "failed to calculate min healthy allowed nodes",
 			"minHealthy", nhc.Spec.MinHealthy, "observedNodes", nhc.Status.ObservedNodes)
@@ -330,17 +681,108 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		msg := fmt.Sprintf("Skipped remediation because the number of healthy nodes selected by the selector is %d and should equal or exceed %d", len(healthyNodes), minHealthy)
 		log.Info(msg)
 		r.Recorder.Event(nhc, eventTypeWarning, eventReasonRemediationSkipped, msg)
+		setRemediationBlockedCondition(nhc, remediationv1alpha1.ConditionReasonMinHealthyNotMet, msg)
 		return
 	}
 
+	// check if the number of unhealthy nodes is in the allowed range
+	if nhc.Spec.UnhealthyRange != "" {
+		if minUnhealthy, maxUnhealthy, err := remediationv1alpha1.ParseUnhealthyRange(nhc.Spec.UnhealthyRange); err != nil {
+			log.Error(err, "failed to parse unhealthy range", "unhealthyRange", nhc.Spec.UnhealthyRange)
+			return result, err
+		} else if len(unhealthyNodes) < minUnhealthy || len(unhealthyNodes) > maxUnhealthy {
+			msg := fmt.Sprintf("Skipped remediation because the number of unhealthy nodes selected by the selector is %d and should be within %s", len(unhealthyNodes), nhc.Spec.UnhealthyRange)
+			log.Info(msg)
+			r.Recorder.Event(nhc, eventTypeWarning, eventReasonRemediationSkipped, msg)
+			setRemediationBlockedCondition(nhc, remediationv1alpha1.ConditionReasonOutsideUnhealthyRange, msg)
+			return
+		}
+	}
+
+	// limit the number of concurrent remediations
+	maxUnhealthy := -1
+	if nhc.Spec.MaxUnhealthy != nil {
+		if maxUnhealthy, err = intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MaxUnhealthy, len(nodes), true); err != nil {
+			log.Error(err, "failed to calculate max unhealthy allowed nodes",
+				"maxUnhealthy", nhc.Spec.MaxUnhealthy, "observedNodes", nhc.Status.ObservedNodes)
+			return result, err
+		}
+	}
+	remediatingNodes := countRemediatingNodes(nhc)
+	var postponedNodes []string
+	var minHealthyDomainNodes []string
+	var maxRemediationsDomainNodes []string
+	maxRemediationsDomains := make(map[string]struct{})
+	var queuedNodes []string
+	var budgetExhaustedNodes []string
+	var budgetExhaustedReason string
+
 	// remediate unhealthy nodes
 	for _, node := range unhealthyNodes {
+		if nhc.DeletionTimestamp != nil && !isRemediatingNode(nhc, node.Name) {
+			// don't start new remediations while waiting for deletion
+			continue
+		}
+		if isFlappingNode(nhc, node.Name) && getFlappingAction(nhc) == remediationv1alpha1.FlappingActionStopRemediation {
+			// the flapping event was emitted already
+			continue
+		}
+		if _, replaced := replacedNodes[node.Name]; replaced {
+			// give the cache some time to notice the deleted remediation CRs of the former node
+			updateResultNextReconcile(&result, 1*time.Second)
+			continue
+		}
+		if _, overridden := overriddenNodes[node.Name]; overridden {
+			// this also gives the cache some time to notice deleted remediation CRs
+			continue
+		}
+		if r.isRemediationDeferredByKubeletProbe(ctx, nhc, &node) {
+			// probe again later
+			updateResultNextReconcile(&result, kubeletProbeRequeueAfter)
+			continue
+		}
+		domain := getFailureDomain(nhc, domains, &node)
+		isNewRemediation := !isRemediatingNode(nhc, node.Name)
+		if isNewRemediation && domain != nil && domain.HealthyNodes < domain.MinHealthy {
+			// nodes getting healthy trigger a new reconcile
+			minHealthyDomainNodes = append(minHealthyDomainNodes, node.Name)
+			continue
+		}
+		if isNewRemediation && maxUnhealthy >= 0 && remediatingNodes >= maxUnhealthy {
+			// finished remediations trigger a new reconcile
+			postponedNodes = append(postponedNodes, node.Name)
+			continue
+		}
+		if isNewRemediation && domain != nil && domain.AvailableRemediations != nil && *domain.AvailableRemediations == 0 {
+			// finished remediations trigger a new reconcile
+			maxRemediationsDomainNodes = append(maxRemediationsDomainNodes, node.Name)
+			maxRemediationsDomains[domain.Name] = struct{}{}
+			continue
+		}
 		nextReconcile, err := r.remediate(&node, nhc, resourceManager)
+		if err == errRemediationRateLimitExceeded {
+			// the rate limit window is checked again after the loop
+			queuedNodes = append(queuedNodes, node.Name)
+			continue
+		}
+		if _, ok := err.(resources.RemediationBudgetExhaustedError); ok {
+			// released budget triggers a new reconcile
+			budgetExhaustedNodes = append(budgetExhaustedNodes, node.Name)
+			budgetExhaustedReason = err.Error()
+			continue
+		}
 		if err != nil {
 			// don't try to remediate other nodes
 			log.Error(err, "failed to start remediation")
 			return result, err
 		}
+		if isNewRemediation && isRemediatingNode(nhc, node.Name) {
+			remediatingNodes++
+			if domain != nil {
+				domain.RemediatingNodes++
+				updateAvailableRemediations(nhc, domain)
+			}
+		}
 		if nextReconcile != nil {
 			updateResultNextReconcile(&result, *nextReconcile)
 		}
@@ -360,64 +802,1470 @@ func (r *NodeHealthCheckReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// the RemediationBlocked condition reports the first reason for skipped or postponed remediations
+	isBlocked := false
+	reportSkippedRemediations := func(reason string, msg string) {
+		log.Info(msg)
+		r.Recorder.Event(nhc, eventTypeWarning, eventReasonRemediationSkipped, msg)
+		if !isBlocked {
+			setRemediationBlockedCondition(nhc, reason, msg)
+			isBlocked = true
+		}
+	}
+	if len(minHealthyDomainNodes) > 0 {
+		msg := fmt.Sprintf("Skipped remediation of nodes %s because their failure domains don't have enough healthy nodes: %s",
+			strings.Join(minHealthyDomainNodes, ", "), formatMinHealthyDomains(nhc))
+		reportSkippedRemediations(remediationv1alpha1.ConditionReasonMinHealthyNotMet, msg)
+	}
+	if len(postponedNodes) > 0 {
+		msg := fmt.Sprintf("Postponed remediation of nodes %s because %d nodes are remediated already, which is the maximum of %d",
+			strings.Join(postponedNodes, ", "), remediatingNodes, maxUnhealthy)
+		reportSkippedRemediations(remediationv1alpha1.ConditionReasonMaxUnhealthyReached, msg)
+	}
+	if len(maxRemediationsDomainNodes) > 0 {
+		msg := fmt.Sprintf("Postponed remediation of nodes %s because their failure domains reached the maximum of %d remediations per domain: %s",
+			strings.Join(maxRemediationsDomainNodes, ", "), *nhc.Spec.Topology.MaxRemediationsPerDomain, formatMaxRemediationsDomains(nhc, maxRemediationsDomains))
+		reportSkippedRemediations(remediationv1alpha1.ConditionReasonMaxRemediationsPerDomainReached, msg)
+	}
+	if len(queuedNodes) > 0 {
+		status := nhc.Status.RemediationRateLimit
+		sort.Strings(queuedNodes)
+		status.QueuedNodes = queuedNodes
+		msg := fmt.Sprintf("Queued remediation of nodes %s because %d remediation CRs were created within %s, and the maximum is %d",
+			strings.Join(queuedNodes, ", "), len(status.RecentRemediations), nhc.Spec.RemediationRateLimit.Window.Duration, nhc.Spec.RemediationRateLimit.MaxRemediations)
+		if nextRemediationAt := getNextRemediationAt(nhc); nextRemediationAt != nil {
+			status.NextRemediationAt = nextRemediationAt
+			updateResultNextReconcile(&result, nextRemediationAt.Sub(currentTime()))
+			msg += fmt.Sprintf(". Next remediation can start at %s", nextRemediationAt.UTC().Format(time.RFC3339))
+		}
+		reportSkippedRemediations(remediationv1alpha1.ConditionReasonRateLimitExceeded, msg)
+	}
+	if len(budgetExhaustedNodes) > 0 {
+		msg := fmt.Sprintf("Postponed remediation of nodes %s because the remediation budget is exhausted: %s",
+			strings.Join(budgetExhaustedNodes, ", "), budgetExhaustedReason)
+		reportSkippedRemediations(remediationv1alpha1.ConditionReasonRemediationBudgetExhausted, msg)
+	}
+	if !isBlocked {
+		setRemediationBlockedCondition(nhc, remediationv1alpha1.ConditionReasonRemediationAllowed, "")
+	}
+
 	return result, nil
 }
 
-func (r *NodeHealthCheckReconciler) isClusterUpgrading() bool {
-	clusterUpgrading, err := r.ClusterUpgradeStatusChecker.Check()
+// updateSelectorOverlapCondition sets the SelectorOverlap condition, which names the other NHCs which select some
+// of the given nodes as well, and the shared nodes
+func (r *NodeHealthCheckReconciler) updateSelectorOverlapCondition(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, rm resources.Manager) error {
+	overlaps, err := rm.GetOverlappingNHCs(nhc, nodes)
 	if err != nil {
-		// if we can't reliably tell if the cluster is upgrading then just continue with remediation.
-		// TODO finer error handling may help to decide otherwise here.
-		r.Log.Error(err, "failed to check if the cluster is upgrading. Proceed with remediation as if it is not upgrading")
+		return errors.Wrapf(err, "failed to check for overlapping selectors")
 	}
-	return clusterUpgrading
+
+	if len(overlaps) == 0 {
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeSelectorOverlap,
+			Status:  metav1.ConditionFalse,
+			Reason:  remediationv1alpha1.ConditionReasonNoSelectorOverlap,
+			Message: "No nodes are selected by other NodeHealthChecks",
+		})
+		return nil
+	}
+
+	otherNHCs := make([]string, 0, len(overlaps))
+	for name := range overlaps {
+		otherNHCs = append(otherNHCs, name)
+	}
+	sort.Strings(otherNHCs)
+	details := make([]string, 0, len(otherNHCs))
+	for _, name := range otherNHCs {
+		details = append(details, fmt.Sprintf("%s (nodes: %s)", name, strings.Join(overlaps[name], ", ")))
+	}
+	message := fmt.Sprintf("Nodes are selected by other NodeHealthChecks as well: %s", strings.Join(details, "; "))
+
+	if !utils.IsConditionTrue(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeSelectorOverlap, remediationv1alpha1.ConditionReasonSelectorOverlapDetected) {
+		utils.GetLogWithNHC(r.Log, nhc).Info("detected overlapping selectors", "overlaps", overlaps)
+		r.Recorder.Event(nhc, eventTypeWarning, eventReasonSelectorOverlap, message)
+	}
+	meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+		Type:    remediationv1alpha1.ConditionTypeSelectorOverlap,
+		Status:  metav1.ConditionTrue,
+		Reason:  remediationv1alpha1.ConditionReasonSelectorOverlapDetected,
+		Message: message,
+	})
+	return nil
 }
 
-func (r *NodeHealthCheckReconciler) checkNodesHealth(nodes []v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) (healthy []v1.Node, unhealthy []v1.Node) {
-	for _, node := range nodes {
-		if r.isHealthy(nhc.Spec.UnhealthyConditions, node.Status.Conditions) {
-			healthy = append(healthy, node)
-		} else if r.MHCChecker.NeedIgnoreNode(&node) {
-			// consider terminating nodes being handled by MHC as healthy, from NHC point of view
-			healthy = append(healthy, node)
+// handleDeletion deletes or orphans the remediation CRs owned by the NHC, depending on its deletion policy,
+// and removes the finalizer afterwards
+func (r *NodeHealthCheckReconciler) handleDeletion(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) error {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+
+	remediationCRs, err := rm.ListRemediationCRs(nhc, func(_ unstructured.Unstructured) bool { return true })
+	if err != nil && !meta.IsNoMatchError(errors.Cause(err)) {
+		return errors.Wrapf(err, "failed to list remediation CRs for deletion")
+	}
+	for _, remediationCR := range remediationCRs {
+		if getDeletionPolicy(nhc) == remediationv1alpha1.DeletionPolicyCancel {
+			if deleted, err := rm.DeleteRemediationCR(&remediationCR, nhc); err != nil {
+				return errors.Wrapf(err, "failed to delete remediation CR %s", remediationCR.GetName())
+			} else if deleted {
+				log.Info("deleted remediation CR because of NHC deletion", "name", remediationCR.GetName())
+				r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationRemoved, "Deleted remediation CR for node %s because of NHC deletion", remediationCR.GetName())
+			}
 		} else {
-			unhealthy = append(unhealthy, node)
+			if orphaned, err := rm.OrphanRemediationCR(&remediationCR, nhc); err != nil {
+				return errors.Wrapf(err, "failed to orphan remediation CR %s", remediationCR.GetName())
+			} else if orphaned {
+				log.Info("orphaned remediation CR because of NHC deletion", "name", remediationCR.GetName())
+				r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationOrphaned, "Orphaned remediation CR for node %s because of NHC deletion", remediationCR.GetName())
+			}
 		}
 	}
-	return
+	return r.removeFinalizer(ctx, nhc, rm)
 }
 
-func (r *NodeHealthCheckReconciler) isHealthy(conditionTests []remediationv1alpha1.UnhealthyCondition, nodeConditions []v1.NodeCondition) bool {
-	nodeConditionByType := make(map[v1.NodeConditionType]v1.NodeCondition)
-	for _, nc := range nodeConditions {
-		nodeConditionByType[nc.Type] = nc
+// removeFinalizer releases the remediation budget of the NHC's remediations, and removes the finalizer
+func (r *NodeHealthCheckReconciler) removeFinalizer(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) error {
+	if !controllerutil.ContainsFinalizer(nhc, nhcFinalizer) {
+		return nil
 	}
+	// ongoing remediations were cancelled, orphaned or waited for, they don't count anymore
+	if err := rm.SyncRemediationBudget(nhc, nil); err != nil {
+		return errors.Wrapf(err, "failed to release remediation budget")
+	}
+	controllerutil.RemoveFinalizer(nhc, nhcFinalizer)
+	if err := r.Update(ctx, nhc); err != nil {
+		return errors.Wrapf(err, "failed to remove finalizer")
+	}
+	return nil
+}
 
-	for _, c := range conditionTests {
-		n, exists := nodeConditionByType[c.Type]
-		if !exists {
-			continue
-		}
-		if n.Status == c.Status && currentTime().After(n.LastTransitionTime.Add(c.Duration.Duration)) {
-			return false
-		}
+func getDeletionPolicy(nhc *remediationv1alpha1.NodeHealthCheck) remediationv1alpha1.DeletionPolicy {
+	if nhc.Spec.DeletionPolicy == "" {
+		return remediationv1alpha1.DeletionPolicyWait
 	}
-	return true
+	return nhc.Spec.DeletionPolicy
 }
 
-func (r *NodeHealthCheckReconciler) remediate(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) (*time.Duration, error) {
+func getNodeDeletionPolicy(nhc *remediationv1alpha1.NodeHealthCheck) remediationv1alpha1.NodeDeletionPolicy {
+	if nhc.Spec.NodeDeletionPolicy == "" {
+		return remediationv1alpha1.NodeDeletionPolicyDelete
+	}
+	return nhc.Spec.NodeDeletionPolicy
+}
 
-	log := utils.GetLogWithNHC(r.Log, nhc)
+func getDeselectedNodePolicy(nhc *remediationv1alpha1.NodeHealthCheck) remediationv1alpha1.DeselectedNodePolicy {
+	if nhc.Spec.DeselectedNodePolicy == "" {
+		return remediationv1alpha1.DeselectedNodePolicyFinish
+	}
+	return nhc.Spec.DeselectedNodePolicy
+}
 
-	// prevent remediation of more than 1 control plane node at a time!
-	isControlPlaneNode := utils.IsControlPlane(node)
-	if isControlPlaneNode {
-		if isAllowed, err := r.isControlPlaneRemediationAllowed(node, nhc, rm); err != nil {
-			return nil, errors.Wrapf(err, "failed to check if control plane remediation is allowed")
-		} else if !isAllowed {
-			log.Info("skipping remediation for preventing control plane quorum loss", "node", node.GetName())
-			r.Recorder.Event(nhc, eventTypeWarning, eventReasonRemediationSkipped, fmt.Sprintf("skipping remediation of %s for preventing control plane quorum loss", node.GetName()))
-			return nil, nil
+// getUnselectedNodeNames returns the names of the nodes in the NHC's status which aren't selected anymore, because
+// they were deleted or because they don't match the selector anymore
+func getUnselectedNodeNames(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node) []string {
+	selectedNodes := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		selectedNodes[node.Name] = struct{}{}
+	}
+	var names []string
+	addName := func(nodeName string) {
+		if _, selected := selectedNodes[nodeName]; selected {
+			return
+		}
+		for _, name := range names {
+			if name == nodeName {
+				return
+			}
+		}
+		names = append(names, nodeName)
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode != nil {
+			addName(unhealthyNode.Name)
+		}
+	}
+	for nodeName := range nhc.Status.InFlightRemediations {
+		addName(nodeName)
+	}
+	return names
+}
+
+// handleDeletedNodes cleans up the nodes in the NHC's status which were deleted
+func (r *NodeHealthCheckReconciler) handleDeletedNodes(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, rm resources.Manager) error {
+	for _, nodeName := range getUnselectedNodeNames(nhc, nodes) {
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &v1.Node{}); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get node %s", nodeName)
+		}
+		if err := r.cleanupDeletedNode(nhc, nodeName, rm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleUnselectedNodes handles existing nodes in the NHC's status which don't match the selector anymore.
+// It returns when these nodes need to be checked again.
+func (r *NodeHealthCheckReconciler) handleUnselectedNodes(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, signals *healthSignals, rm resources.Manager) (*time.Duration, error) {
+	var nextCheck *time.Duration
+	for _, nodeName := range getUnselectedNodeNames(nhc, nodes) {
+		node := &v1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				// the deletion triggers a new reconcile, which cleans up the node
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get node %s", nodeName)
+		}
+		next, err := r.handleDeselectedNode(nhc, node, signals, rm)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil && (nextCheck == nil || *next < *nextCheck) {
+			nextCheck = next
+		}
+	}
+	return nextCheck, nil
+}
+
+// handleReplacedNodes detects selected nodes which replaced a former node with the same name, by comparing their UID
+// with the UID in the status and on the remediation CRs. The remediation of the former node is considered as
+// completed, its remediation CRs are deleted or orphaned depending on the node deletion policy, and the node's status
+// is reset, so that the new node is checked from scratch. It returns the former UIDs of the replaced nodes by name.
+func (r *NodeHealthCheckReconciler) handleReplacedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, rm resources.Manager) (map[string]types.UID, error) {
+	nodeUIDs := make(map[string]types.UID, len(nodes))
+	for _, node := range nodes {
+		nodeUIDs[node.Name] = node.UID
+	}
+	isReplaced := func(nodeName string, uid types.UID) bool {
+		currentUID, selected := nodeUIDs[nodeName]
+		return selected && uid != "" && uid != currentUID
+	}
+
+	replacedNodes := make(map[string]types.UID)
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode != nil && isReplaced(unhealthyNode.Name, unhealthyNode.UID) {
+			replacedNodes[unhealthyNode.Name] = unhealthyNode.UID
+		}
+	}
+	remediationCRs, err := rm.ListRemediationCRs(nhc, func(cr unstructured.Unstructured) bool {
+		return isReplaced(cr.GetName(), types.UID(cr.GetAnnotations()[resources.NodeUIDAnnotationKey]))
+	})
+	if err != nil && !meta.IsNoMatchError(errors.Cause(err)) {
+		return nil, errors.Wrapf(err, "failed to list remediation CRs of replaced nodes")
+	}
+	for _, remediationCR := range remediationCRs {
+		if _, exists := replacedNodes[remediationCR.GetName()]; !exists {
+			replacedNodes[remediationCR.GetName()] = types.UID(remediationCR.GetAnnotations()[resources.NodeUIDAnnotationKey])
+		}
+	}
+
+	for nodeName, formerUID := range replacedNodes {
+		utils.GetLogWithNHC(r.Log, nhc).Info("node was replaced", "node", nodeName, "former UID", formerUID, "UID", nodeUIDs[nodeName])
+		orphan := getNodeDeletionPolicy(nhc) == remediationv1alpha1.NodeDeletionPolicyOrphan
+		// CRs of the new node, if any, have to be kept
+		if err := r.removeRemediationCRsMatching(nhc, nodeName, orphan, "the node was replaced", rm, func(cr unstructured.Unstructured) bool {
+			return types.UID(cr.GetAnnotations()[resources.NodeUIDAnnotationKey]) != nodeUIDs[nodeName]
+		}); err != nil {
+			return nil, err
+		}
+		if isRemediatingNode(nhc, nodeName) {
+			r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationCompleted, "Remediation of node %s completed, the node was replaced", nodeName)
+		}
+		resources.UpdateStatusNodeRemoved(nodeName, nhc)
+		r.forgetNode(nhc, nodeName)
+	}
+	return replacedNodes, nil
+}
+
+// cleanupDeletedNode handles a node in the NHC's status which doesn't exist anymore. Its remediation is considered as
+// completed, its remediation CRs are deleted or orphaned depending on the node deletion policy, and it is removed
+// from the status.
+func (r *NodeHealthCheckReconciler) cleanupDeletedNode(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, rm resources.Manager) error {
+	orphan := getNodeDeletionPolicy(nhc) == remediationv1alpha1.NodeDeletionPolicyOrphan
+	if err := r.removeRemediationCRs(nhc, nodeName, orphan, "the node was deleted", rm); err != nil {
+		return err
+	}
+	if isRemediatingNode(nhc, nodeName) {
+		utils.GetLogWithNHC(r.Log, nhc).Info("remediation completed, the node was deleted", "node", nodeName)
+		r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationCompleted, "Remediation of node %s completed, the node was deleted", nodeName)
+	}
+	resources.UpdateStatusNodeRemoved(nodeName, nhc)
+	r.forgetNode(nhc, nodeName)
+	return nil
+}
+
+// handleDeselectedNode handles an existing node in the NHC's status which isn't selected anymore. Depending on the
+// deselected node policy, its remediation is cancelled immediately, or when the node is healthy again or its current
+// remediation timed out. It returns when the node needs to be checked again.
+func (r *NodeHealthCheckReconciler) handleDeselectedNode(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, signals *healthSignals, rm resources.Manager) (*time.Duration, error) {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+
+	reason := "the node isn't selected anymore"
+	completed := false
+	if getDeselectedNodePolicy(nhc) == remediationv1alpha1.DeselectedNodePolicyFinish && isRemediatingNode(nhc, node.Name) {
+		healthy, _, nextHealthCheck := r.checkNodesHealth([]v1.Node{*node}, signals, nhc)
+		if len(healthy) > 0 {
+			reason = "the node isn't selected anymore and is healthy"
+			completed = true
+		} else {
+			timedOut, nextTimeout, err := r.isRemediationTimedOut(nhc, node, rm)
+			if err != nil {
+				return nil, err
+			}
+			if !timedOut {
+				// let the ongoing remediation finish
+				if nextTimeout != nil && (nextHealthCheck == nil || *nextTimeout < *nextHealthCheck) {
+					return nextTimeout, nil
+				}
+				return nextHealthCheck, nil
+			}
+			reason = "the node isn't selected anymore and its remediation timed out"
+		}
+	}
+
+	if err := r.removeRemediationCRs(nhc, node.Name, false, reason, rm); err != nil {
+		return nil, err
+	}
+	if completed {
+		log.Info("remediation completed", "node", node.Name, "reason", reason)
+		r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationCompleted, "Remediation of node %s completed, %s", node.Name, reason)
+	} else if isRemediatingNode(nhc, node.Name) {
+		log.Info("remediation cancelled", "node", node.Name, "reason", reason)
+		r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationCancelled, "Remediation of node %s cancelled, %s", node.Name, reason)
+	}
+	resources.UpdateStatusNodeRemoved(node.Name, nhc)
+	r.forgetNode(nhc, node.Name)
+	return nil, nil
+}
+
+// isRemediationTimedOut checks if the current remediation of the given node timed out. If not, it returns when it
+// will time out, if it has a timeout at all.
+func (r *NodeHealthCheckReconciler) isRemediationTimedOut(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, rm resources.Manager) (bool, *time.Duration, error) {
+	_, timeout, err := rm.GetCurrentTemplateWithTimeout(node, nhc)
+	if err != nil {
+		if _, ok := err.(resources.NoTemplateLeftError); ok {
+			// all remediations timed out already
+			return true, nil, nil
+		}
+		return false, nil, errors.Wrapf(err, "failed to get current template")
+	}
+	if timeout == nil {
+		// no timeout set for classic remediation
+		return false, nil, nil
+	}
+	remediationCRs, err := rm.ListRemediationCRs(nhc, func(cr unstructured.Unstructured) bool {
+		return cr.GetName() == node.GetName()
+	})
+	if err != nil {
+		return false, nil, errors.Wrapf(err, "failed to list remediation CRs of node %s", node.Name)
+	}
+	now := currentTime()
+	for i := range remediationCRs {
+		remediationCR := &remediationCRs[i]
+		startedRemediation := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+			return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() && r.TimedOut == nil
+		})
+		if startedRemediation == nil {
+			continue
+		}
+		timeoutAt := getTimeoutAt(remediationCR, startedRemediation, timeout, utils.GetLogWithNHC(r.Log, nhc))
+		if !now.Before(timeoutAt) {
+			return true, nil, nil
+		}
+		return false, pointer.Duration(timeoutAt.Sub(now)), nil
+	}
+	return false, nil, nil
+}
+
+// removeRemediationCRs deletes or orphans the remediation CRs of the given node, and explains why in an event
+func (r *NodeHealthCheckReconciler) removeRemediationCRs(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, orphan bool, reason string, rm resources.Manager) error {
+	return r.removeRemediationCRsMatching(nhc, nodeName, orphan, reason, rm, func(_ unstructured.Unstructured) bool { return true })
+}
+
+// removeRemediationCRsMatching deletes or orphans the remediation CRs of the given node which match the given filter,
+// and explains why in an event
+func (r *NodeHealthCheckReconciler) removeRemediationCRsMatching(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, orphan bool, reason string, rm resources.Manager, filter func(cr unstructured.Unstructured) bool) error {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+
+	remediationCRs, err := rm.ListRemediationCRs(nhc, func(cr unstructured.Unstructured) bool {
+		return cr.GetName() == nodeName && filter(cr)
+	})
+	if err != nil && !meta.IsNoMatchError(errors.Cause(err)) {
+		return errors.Wrapf(err, "failed to list remediation CRs of node %s", nodeName)
+	}
+	for _, remediationCR := range remediationCRs {
+		if orphan {
+			if orphaned, err := rm.OrphanRemediationCR(&remediationCR, nhc); err != nil {
+				return errors.Wrapf(err, "failed to orphan remediation CR %s", remediationCR.GetName())
+			} else if orphaned {
+				log.Info("orphaned remediation CR", "name", remediationCR.GetName(), "reason", reason)
+				r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationOrphaned, "Orphaned remediation CR for node %s because %s", remediationCR.GetName(), reason)
+			}
+		} else {
+			if deleted, err := rm.DeleteRemediationCR(&remediationCR, nhc); err != nil {
+				return errors.Wrapf(err, "failed to delete remediation CR %s", remediationCR.GetName())
+			} else if deleted {
+				log.Info("deleted remediation CR", "name", remediationCR.GetName(), "reason", reason)
+				r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationRemoved, "Deleted remediation CR for node %s because %s", remediationCR.GetName(), reason)
+			}
+		}
+	}
+	return nil
+}
+
+// countRemediatingNodes returns the number of nodes under remediation
+func countRemediatingNodes(nhc *remediationv1alpha1.NodeHealthCheck) int {
+	remediatingNodes := make(map[string]struct{})
+	for nodeName := range nhc.Status.InFlightRemediations {
+		remediatingNodes[nodeName] = struct{}{}
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode != nil && len(unhealthyNode.Remediations) > 0 {
+			remediatingNodes[unhealthyNode.Name] = struct{}{}
+		}
+	}
+	return len(remediatingNodes)
+}
+
+// setRemediationBlockedCondition sets the RemediationBlocked condition, which is false for the RemediationAllowed reason
+func setRemediationBlockedCondition(nhc *remediationv1alpha1.NodeHealthCheck, reason string, message string) {
+	status := metav1.ConditionTrue
+	if reason == remediationv1alpha1.ConditionReasonRemediationAllowed {
+		status = metav1.ConditionFalse
+		message = "Remediation isn't blocked by minHealthy, unhealthyRange, maxUnhealthy, topology, remediationRateLimit or the remediation budget"
+	}
+	meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+		Type:    remediationv1alpha1.ConditionTypeRemediationBlocked,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func isRemediatingNode(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) bool {
+	if _, exists := nhc.Status.InFlightRemediations[nodeName]; exists {
+		return true
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		// nodes with deferred remediation don't have remediations
+		if unhealthyNode != nil && unhealthyNode.Name == nodeName && len(unhealthyNode.Remediations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isRemediationDeferredByKubeletProbe probes the kubelet of nodes which fail the NHC's unhealthy conditions and
+// aren't remediated yet. When the kubelet responds, the node is probably only disconnected from the API server,
+// and its remediation is deferred.
+func (r *NodeHealthCheckReconciler) isRemediationDeferredByKubeletProbe(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) bool {
+	if nhc.Spec.KubeletProbe == nil || isRemediatingNode(nhc, node.Name) {
+		return false
+	}
+	if conditionsHealthy, _ := r.isHealthy(nhc, node); conditionsHealthy {
+		// the node is unhealthy because of other reasons, probing the kubelet doesn't help
+		return false
+	}
+
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	result := &remediationv1alpha1.KubeletProbeResult{
+		Result: remediationv1alpha1.KubeletProbeResultResponding,
+		Since:  metav1.NewTime(currentTime()),
+	}
+	probeURL, err := kubelet.GetProbeURL(node, nhc.Spec.KubeletProbe)
+	if err == nil {
+		err = kubelet.Probe(ctx, probeURL, kubelet.GetTimeout(nhc.Spec.KubeletProbe))
+	}
+	if err != nil {
+		result.Result = remediationv1alpha1.KubeletProbeResultNotResponding
+		result.Message = err.Error()
+	} else {
+		result.Message = fmt.Sprintf("Kubelet responded at %s", probeURL)
+	}
+
+	// only update the status when the result changes, since status updates trigger reconciles
+	previous := getStatusKubeletProbe(nhc, node.Name)
+	if previous != nil && previous.Result == result.Result {
+		return result.Result == remediationv1alpha1.KubeletProbeResultResponding
+	}
+	resources.UpdateStatusKubeletProbe(node, nhc, result)
+
+	if result.Result == remediationv1alpha1.KubeletProbeResultResponding {
+		log.Info("deferring remediation, kubelet is responding", "node", node.Name)
+		r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonRemediationDeferred, "Deferring remediation of node %s, its kubelet is responding", node.Name)
+		return true
+	}
+	log.Info("kubelet probe confirmed unhealthy node", "node", node.Name, "reason", result.Message)
+	return false
+}
+
+// getStatusKubeletProbe returns the kubelet probe result of the given node from the NHC's status
+func getStatusKubeletProbe(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) *remediationv1alpha1.KubeletProbeResult {
+	if unhealthyNode := resources.FindStatusUnhealthyNode(nodeName, nhc); unhealthyNode != nil {
+		return unhealthyNode.KubeletProbe
+	}
+	return nil
+}
+
+// updateFailureDomains counts the observed, healthy and remediating nodes of each failure domain, and updates the
+// FailureDomains status. Nodes without the topology label don't belong to any failure domain.
+// It returns the domains by name, or nil if no Topology is configured.
+func updateFailureDomains(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, healthyNodes []v1.Node) (map[string]*remediationv1alpha1.FailureDomain, error) {
+	if nhc.Spec.Topology == nil {
+		nhc.Status.FailureDomains = nil
+		return nil, nil
+	}
+
+	counts := make(map[string]*remediationv1alpha1.FailureDomain)
+	for _, node := range nodes {
+		name, hasLabel := node.Labels[nhc.Spec.Topology.Key]
+		if !hasLabel {
+			continue
+		}
+		domain, exists := counts[name]
+		if !exists {
+			domain = &remediationv1alpha1.FailureDomain{Name: name}
+			counts[name] = domain
+		}
+		domain.ObservedNodes++
+		if isRemediatingNode(nhc, node.Name) {
+			domain.RemediatingNodes++
+		}
+	}
+	for _, node := range healthyNodes {
+		if name, hasLabel := node.Labels[nhc.Spec.Topology.Key]; hasLabel {
+			counts[name].HealthyNodes++
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	failureDomains := make([]remediationv1alpha1.FailureDomain, 0, len(names))
+	for _, name := range names {
+		domain := counts[name]
+		minHealthy, err := intstr.GetScaledValueFromIntOrPercent(nhc.Spec.MinHealthy, domain.ObservedNodes, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate min healthy nodes of failure domain %q", name)
+		}
+		domain.MinHealthy = minHealthy
+		failureDomains = append(failureDomains, *domain)
+	}
+	nhc.Status.FailureDomains = failureDomains
+
+	// point to the status, so that it's updated with new remediations
+	domains := make(map[string]*remediationv1alpha1.FailureDomain, len(failureDomains))
+	for i := range nhc.Status.FailureDomains {
+		domain := &nhc.Status.FailureDomains[i]
+		updateAvailableRemediations(nhc, domain)
+		domains[domain.Name] = domain
+	}
+	return domains, nil
+}
+
+// updateAvailableRemediations updates the number of new remediations which can be started in the given failure domain
+func updateAvailableRemediations(nhc *remediationv1alpha1.NodeHealthCheck, domain *remediationv1alpha1.FailureDomain) {
+	if domain.HealthyNodes < domain.MinHealthy {
+		domain.AvailableRemediations = pointer.Int(0)
+		return
+	}
+	maxRemediations := nhc.Spec.Topology.MaxRemediationsPerDomain
+	if maxRemediations == nil {
+		domain.AvailableRemediations = nil
+		return
+	}
+	available := *maxRemediations - domain.RemediatingNodes
+	if available < 0 {
+		available = 0
+	}
+	domain.AvailableRemediations = &available
+}
+
+// getFailureDomain returns the failure domain of the given node, or nil if no Topology is configured or the node
+// doesn't have the topology label
+func getFailureDomain(nhc *remediationv1alpha1.NodeHealthCheck, domains map[string]*remediationv1alpha1.FailureDomain, node *v1.Node) *remediationv1alpha1.FailureDomain {
+	if nhc.Spec.Topology == nil {
+		return nil
+	}
+	name, hasLabel := node.Labels[nhc.Spec.Topology.Key]
+	if !hasLabel {
+		return nil
+	}
+	return domains[name]
+}
+
+// formatMinHealthyDomains returns a description of the failure domains which don't have enough healthy nodes
+func formatMinHealthyDomains(nhc *remediationv1alpha1.NodeHealthCheck) string {
+	var descriptions []string
+	for _, domain := range nhc.Status.FailureDomains {
+		if domain.HealthyNodes < domain.MinHealthy {
+			descriptions = append(descriptions, fmt.Sprintf("%q has %d healthy nodes and needs %d", domain.Name, domain.HealthyNodes, domain.MinHealthy))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// formatMaxRemediationsDomains returns a description of the given failure domains, which reached the maximum number of
+// remediations
+func formatMaxRemediationsDomains(nhc *remediationv1alpha1.NodeHealthCheck, names map[string]struct{}) string {
+	var descriptions []string
+	for _, domain := range nhc.Status.FailureDomains {
+		if _, exists := names[domain.Name]; exists {
+			descriptions = append(descriptions, fmt.Sprintf("%q has %d remediating nodes", domain.Name, domain.RemediatingNodes))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// updateRemediationRateLimit removes remediations which left the RemediationRateLimit window from the status,
+// and resets the queued nodes, which are determined again when remediating unhealthy nodes
+func updateRemediationRateLimit(nhc *remediationv1alpha1.NodeHealthCheck) {
+	rl := nhc.Spec.RemediationRateLimit
+	if rl == nil {
+		nhc.Status.RemediationRateLimit = nil
+		return
+	}
+	status := nhc.Status.RemediationRateLimit
+	if status == nil {
+		status = &remediationv1alpha1.RemediationRateLimitStatus{}
+		nhc.Status.RemediationRateLimit = status
+	}
+
+	now := currentTime()
+	var recentRemediations []metav1.Time
+	for _, started := range status.RecentRemediations {
+		if started.Add(rl.Window.Duration).After(now) {
+			recentRemediations = append(recentRemediations, started)
+		}
+	}
+	status.RecentRemediations = recentRemediations
+	status.QueuedNodes = nil
+	status.NextRemediationAt = nil
+}
+
+// getRemediationRateLimitBudget returns how many remediation CRs can be created within the current
+// RemediationRateLimit window, or -1 if there is no limit
+func getRemediationRateLimitBudget(nhc *remediationv1alpha1.NodeHealthCheck) int {
+	rl := nhc.Spec.RemediationRateLimit
+	if rl == nil || nhc.Status.RemediationRateLimit == nil {
+		return -1
+	}
+	if budget := rl.MaxRemediations - len(nhc.Status.RemediationRateLimit.RecentRemediations); budget > 0 {
+		return budget
+	}
+	return 0
+}
+
+// recordRateLimitedRemediation counts a created remediation CR for the RemediationRateLimit
+func recordRateLimitedRemediation(nhc *remediationv1alpha1.NodeHealthCheck) {
+	if status := nhc.Status.RemediationRateLimit; status != nil {
+		status.RecentRemediations = append(status.RecentRemediations, metav1.NewTime(currentTime()))
+	}
+}
+
+// getNextRemediationAt returns when enough of the counted remediation CRs left the RemediationRateLimit window for
+// creating the next one, or nil if nothing is counted
+func getNextRemediationAt(nhc *remediationv1alpha1.NodeHealthCheck) *metav1.Time {
+	rl := nhc.Spec.RemediationRateLimit
+	status := nhc.Status.RemediationRateLimit
+	if rl == nil || status == nil || len(status.RecentRemediations) == 0 {
+		return nil
+	}
+	created := make([]time.Time, 0, len(status.RecentRemediations))
+	for _, t := range status.RecentRemediations {
+		created = append(created, t.Time)
+	}
+	sort.Slice(created, func(i, j int) bool { return created[i].Before(created[j]) })
+	// the limit might be exceeded already, e.g. after it was lowered, so more than the oldest CR might need to leave
+	// the window
+	leaving := len(created) - rl.MaxRemediations
+	if leaving < 0 {
+		leaving = 0
+	} else if leaving >= len(created) {
+		leaving = len(created) - 1
+	}
+	nextRemediationAt := metav1.NewTime(created[leaving].Add(rl.Window.Duration))
+	return &nextRemediationAt
+}
+
+// updateStorm tracks since when nodes are unhealthy for the StormDetection, detects storms of nodes becoming
+// unhealthy, and ends storms after the stabilization period.
+// It returns the duration until the storm needs to be checked again.
+func (r *NodeHealthCheckReconciler) updateStorm(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, unhealthyNodes []v1.Node) (*time.Duration, error) {
+	sd := nhc.Spec.StormDetection
+	if sd == nil {
+		nhc.Status.Storm = nil
+		meta.RemoveStatusCondition(&nhc.Status.Conditions, remediationv1alpha1.ConditionTypeStormDetected)
+		return nil, nil
+	}
+
+	now := metav1.NewTime(currentTime())
+	status := nhc.Status.Storm
+	// nodes which are unhealthy already when storm detection is configured aren't counted
+	isNewStatus := status == nil
+	if isNewStatus {
+		status = &remediationv1alpha1.StormStatus{}
+		nhc.Status.Storm = status
+	}
+
+	unhealthySince := make(map[string]*metav1.Time, len(status.UnhealthyNodes))
+	for _, unhealthyNode := range status.UnhealthyNodes {
+		unhealthySince[unhealthyNode.Name] = unhealthyNode.Since
+	}
+	var trackedNodes []remediationv1alpha1.StormUnhealthyNode
+	for _, node := range unhealthyNodes {
+		since, isTracked := unhealthySince[node.Name]
+		if !isTracked && !isNewStatus {
+			since = now.DeepCopy()
+			status.LastUnhealthyTransition = now.DeepCopy()
+		}
+		trackedNodes = append(trackedNodes, remediationv1alpha1.StormUnhealthyNode{Name: node.Name, Since: since})
+	}
+	status.UnhealthyNodes = trackedNodes
+
+	maxNewUnhealthy, err := intstr.GetScaledValueFromIntOrPercent(&sd.MaxNewUnhealthyNodes, len(nodes), true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate max new unhealthy nodes")
+	}
+	newUnhealthy := 0
+	var nextExpiry *time.Duration
+	for _, trackedNode := range trackedNodes {
+		if trackedNode.Since == nil {
+			continue
+		}
+		if remaining := trackedNode.Since.Add(sd.Window.Duration).Sub(now.Time); remaining > 0 {
+			newUnhealthy++
+			if nextExpiry == nil || remaining < *nextExpiry {
+				nextExpiry = &remaining
+			}
+		}
+	}
+
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	if newUnhealthy > maxNewUnhealthy {
+		msg := fmt.Sprintf("%d nodes became unhealthy within %s, which is more than the maximum of %d", newUnhealthy, sd.Window.Duration, maxNewUnhealthy)
+		if status.DetectedAt == nil {
+			status.DetectedAt = now.DeepCopy()
+			log.Info("storm detected, pausing remediation", "newUnhealthyNodes", newUnhealthy, "maxNewUnhealthyNodes", maxNewUnhealthy)
+			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonStormDetected, "Storm detected, %s. Pausing remediation", msg)
+		}
+		meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+			Type:    remediationv1alpha1.ConditionTypeStormDetected,
+			Status:  metav1.ConditionTrue,
+			Reason:  remediationv1alpha1.ConditionReasonStormThresholdExceeded,
+			Message: msg,
+		})
+		return nextExpiry, nil
+	}
+
+	if status.DetectedAt != nil {
+		if last := status.LastUnhealthyTransition; last != nil {
+			if remaining := last.Add(sd.StabilizationPeriod.Duration).Sub(now.Time); remaining > 0 {
+				meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+					Type:    remediationv1alpha1.ConditionTypeStormDetected,
+					Status:  metav1.ConditionTrue,
+					Reason:  remediationv1alpha1.ConditionReasonStormStabilizing,
+					Message: fmt.Sprintf("Waiting until no node became unhealthy for %s", sd.StabilizationPeriod.Duration),
+				})
+				return &remaining, nil
+			}
+		}
+		status.DetectedAt = nil
+		log.Info("storm ended, resuming remediation")
+		r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonStormEnded, "Storm ended, no node became unhealthy for %s. Resuming remediation", sd.StabilizationPeriod.Duration)
+	}
+	meta.SetStatusCondition(&nhc.Status.Conditions, metav1.Condition{
+		Type:    remediationv1alpha1.ConditionTypeStormDetected,
+		Status:  metav1.ConditionFalse,
+		Reason:  remediationv1alpha1.ConditionReasonNoStorm,
+		Message: "No storm of unhealthy nodes detected",
+	})
+	return nil, nil
+}
+
+func isStormDetected(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	return nhc.Spec.StormDetection != nil && nhc.Status.Storm != nil && nhc.Status.Storm.DetectedAt != nil
+}
+
+// updateHealthTransitions tracks since when nodes in the NHC's status look healthy again, and detects flapping nodes.
+// It returns the duration until the next healthy transition leaves the flapping detection window.
+func (r *NodeHealthCheckReconciler) updateHealthTransitions(nhc *remediationv1alpha1.NodeHealthCheck, healthyNodes []v1.Node, unhealthyNodes []v1.Node) *time.Duration {
+	if nhc.Spec.HealthyStabilizationWindow == nil && nhc.Spec.FlappingDetection == nil {
+		return nil
+	}
+
+	now := metav1.NewTime(currentTime())
+	for _, node := range unhealthyNodes {
+		if status := resources.FindStatusUnhealthyNode(node.Name, nhc); status != nil {
+			status.HealthySince = nil
+		}
+	}
+	for _, node := range healthyNodes {
+		status := resources.FindStatusUnhealthyNode(node.Name, nhc)
+		if status == nil || status.HealthySince != nil {
+			continue
+		}
+		status.HealthySince = now.DeepCopy()
+		if nhc.Spec.FlappingDetection != nil {
+			status.HealthyTransitions = append(status.HealthyTransitions, now)
+		}
+	}
+
+	fd := nhc.Spec.FlappingDetection
+	if fd == nil {
+		return nil
+	}
+	log := utils.GetLogWithNHC(r.Log, nhc)
+	var nextExpiry *time.Duration
+	for _, status := range nhc.Status.UnhealthyNodes {
+		if status == nil {
+			continue
+		}
+		var transitions []metav1.Time
+		for _, transition := range status.HealthyTransitions {
+			if remaining := transition.Add(fd.Window.Duration).Sub(now.Time); remaining > 0 {
+				transitions = append(transitions, transition)
+				if nextExpiry == nil || remaining < *nextExpiry {
+					nextExpiry = &remaining
+				}
+			}
+		}
+		status.HealthyTransitions = transitions
+
+		isFlapping := len(transitions) > fd.MaxTransitions
+		if isFlapping && status.FlappingSince == nil {
+			status.FlappingSince = now.DeepCopy()
+			log.Info("node is flapping", "node", status.Name, "transitions", len(transitions), "action", getFlappingAction(nhc))
+			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonNodeFlapping, "Node %s is flapping, it became healthy %d times within %s. Action: %s",
+				status.Name, len(transitions), fd.Window.Duration, getFlappingAction(nhc))
+		} else if !isFlapping && status.FlappingSince != nil {
+			log.Info("node stopped flapping", "node", status.Name)
+			status.FlappingSince = nil
+		}
+	}
+	return nextExpiry
+}
+
+// getHealthyStabilizationRemaining returns how long the given node needs to stay healthy, before its remediation
+// can be stopped, or nil if it can be stopped already
+func getHealthyStabilizationRemaining(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) *time.Duration {
+	if nhc.Spec.HealthyStabilizationWindow == nil {
+		return nil
+	}
+	status := resources.FindStatusUnhealthyNode(nodeName, nhc)
+	if status == nil || status.HealthySince == nil {
+		return nil
+	}
+	remaining := status.HealthySince.Add(nhc.Spec.HealthyStabilizationWindow.Duration).Sub(currentTime())
+	if remaining <= 0 {
+		return nil
+	}
+	return &remaining
+}
+
+func isFlappingNode(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) bool {
+	status := resources.FindStatusUnhealthyNode(nodeName, nhc)
+	return status != nil && status.FlappingSince != nil
+}
+
+func getFlappingAction(nhc *remediationv1alpha1.NodeHealthCheck) remediationv1alpha1.FlappingAction {
+	if nhc.Spec.FlappingDetection == nil {
+		return ""
+	}
+	if nhc.Spec.FlappingDetection.Action == "" {
+		return remediationv1alpha1.FlappingActionStopRemediation
+	}
+	return nhc.Spec.FlappingDetection.Action
+}
+
+func (r *NodeHealthCheckReconciler) isClusterUpgrading() bool {
+	clusterUpgrading, err := r.ClusterUpgradeStatusChecker.Check()
+	if err != nil {
+		// if we can't reliably tell if the cluster is upgrading then just continue with remediation.
+		// TODO finer error handling may help to decide otherwise here.
+		r.Log.Error(err, "failed to check if the cluster is upgrading. Proceed with remediation as if it is not upgrading")
+	}
+	return clusterUpgrading
+}
+
+// healthSignals contains data about the NHC's nodes, which is used in addition to the node's conditions
+// for checking node health
+type healthSignals struct {
+	// leases contains the node leases by node name
+	leases map[string]coordinationv1.Lease
+	// notReadyPods contains the not ready pods selected by the NHC's unhealthyPods, by node name
+	notReadyPods map[string][]notReadyPod
+	// unhealthySignals contains the not expired NodeHealthSignals selected by the NHC's unhealthySignals, by node name
+	unhealthySignals map[string][]remediationv1alpha1.NodeHealthSignal
+	// prometheusBreaches contains the names of nodes whose prometheus query result breaches the threshold.
+	// It's nil when the query failed.
+	prometheusBreaches map[string]bool
+}
+
+// notReadyPod is a pod selected by the NHC's unhealthyPods, which isn't ready
+type notReadyPod struct {
+	name     string
+	since    time.Time
+	duration time.Duration
+}
+
+// getHealthSignals fetches the data needed by the NHC's optional health checks
+func (r *NodeHealthCheckReconciler) getHealthSignals(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) (*healthSignals, error) {
+	signals := &healthSignals{
+		notReadyPods:     make(map[string][]notReadyPod),
+		unhealthySignals: make(map[string][]remediationv1alpha1.NodeHealthSignal),
+	}
+	if nhc.Spec.StaleLeaseDuration != nil {
+		leases, err := rm.GetNodeLeases()
+		if err != nil {
+			return nil, err
+		}
+		signals.leases = leases
+	}
+	if len(nhc.Spec.UnhealthyPods) > 0 {
+		if err := r.addPodWatch(); err != nil {
+			return nil, errors.Wrap(err, "failed to add watch for pods")
+		}
+	}
+	for _, unhealthyPod := range nhc.Spec.UnhealthyPods {
+		pods, err := rm.GetPods(unhealthyPod.Namespace, unhealthyPod.Selector)
+		if err != nil {
+			return nil, err
+		}
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Spec.NodeName == "" || !utils.IsMatchingPod(unhealthyPod, pod) {
+				continue
+			}
+			if since := utils.GetPodNotReadySince(pod); since != nil {
+				signals.notReadyPods[pod.Spec.NodeName] = append(signals.notReadyPods[pod.Spec.NodeName], notReadyPod{
+					name:     pod.Namespace + "/" + pod.Name,
+					since:    *since,
+					duration: unhealthyPod.Duration.Duration,
+				})
+			}
+		}
+	}
+	if len(nhc.Spec.UnhealthySignals) > 0 {
+		nodeHealthSignals, err := rm.GetNodeHealthSignals()
+		if err != nil {
+			return nil, err
+		}
+		now := metav1.NewTime(currentTime())
+		for _, signal := range nodeHealthSignals {
+			if !signal.IsExpired(now) && isUnhealthySignal(nhc, &signal) {
+				signals.unhealthySignals[signal.Spec.NodeName] = append(signals.unhealthySignals[signal.Spec.NodeName], signal)
+			}
+		}
+	}
+	if nhc.Spec.PrometheusQuery != nil {
+		breaches, err := r.getPrometheusBreaches(ctx, nhc.Spec.PrometheusQuery)
+		if err != nil {
+			// don't block other health checks because of an unavailable prometheus
+			utils.GetLogWithNHC(r.Log, nhc).Error(err, "failed to run prometheus query")
+			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonPrometheusQueryFailed, "Failed to run prometheus query: %v", err)
+		}
+		signals.prometheusBreaches = breaches
+	}
+	return signals, nil
+}
+
+// getPrometheusBreaches runs the given query, and returns the names of nodes whose value breaches the threshold
+func (r *NodeHealthCheckReconciler) getPrometheusBreaches(ctx context.Context, pq *remediationv1alpha1.PrometheusQuery) (map[string]bool, error) {
+	threshold, err := strconv.ParseFloat(pq.Threshold, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid threshold")
+	}
+	query := prometheus.Query{
+		URL:                   pq.URL,
+		Query:                 pq.Query,
+		InsecureSkipTLSVerify: pq.InsecureSkipTLSVerify,
+	}
+	if pq.UseServiceAccountToken {
+		query.BearerTokenFile = prometheus.ServiceAccountTokenFile
+	}
+	samples, err := r.prometheusClient.Query(ctx, query, getPrometheusInterval(pq))
+	if err != nil {
+		return nil, err
+	}
+
+	nodeLabel := pq.NodeLabel
+	if nodeLabel == "" {
+		nodeLabel = defaultPrometheusNodeLabel
+	}
+	breaches := make(map[string]bool)
+	for _, sample := range samples {
+		nodeName, exists := sample.Metric[nodeLabel]
+		if !exists {
+			continue
+		}
+		if breachesThreshold(sample.Value, threshold, pq.Operator) {
+			breaches[nodeName] = true
+		}
+	}
+	return breaches, nil
+}
+
+func breachesThreshold(value float64, threshold float64, operator remediationv1alpha1.ThresholdOperator) bool {
+	switch operator {
+	case remediationv1alpha1.ThresholdOperatorGreaterThanOrEqual:
+		return value >= threshold
+	case remediationv1alpha1.ThresholdOperatorLessThan:
+		return value < threshold
+	case remediationv1alpha1.ThresholdOperatorLessThanOrEqual:
+		return value <= threshold
+	default:
+		return value > threshold
+	}
+}
+
+func getPrometheusInterval(pq *remediationv1alpha1.PrometheusQuery) time.Duration {
+	if pq.Interval == nil {
+		return defaultPrometheusInterval
+	}
+	return pq.Interval.Duration
+}
+
+// isUnhealthySignal checks if the given NodeHealthSignal is selected by the NHC's unhealthySignals
+func isUnhealthySignal(nhc *remediationv1alpha1.NodeHealthCheck, signal *remediationv1alpha1.NodeHealthSignal) bool {
+	for _, unhealthySignal := range nhc.Spec.UnhealthySignals {
+		if unhealthySignal.Source != signal.Spec.Source {
+			continue
+		}
+		minSeverity := unhealthySignal.MinSeverity
+		if minSeverity == "" {
+			minSeverity = remediationv1alpha1.SignalSeverityCritical
+		}
+		if signal.Spec.Severity.IsAtLeast(minSeverity) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *NodeHealthCheckReconciler) checkNodesHealth(nodes []v1.Node, signals *healthSignals, nhc *remediationv1alpha1.NodeHealthCheck) (healthy []v1.Node, unhealthy []v1.Node, nextCheck *time.Duration) {
+	unhealthyExpression := r.compileUnhealthyExpression(nhc)
+	updateNextCheck := func(expiresIn *time.Duration) {
+		if expiresIn != nil && (nextCheck == nil || *expiresIn < *nextCheck) {
+			nextCheck = expiresIn
+		}
+	}
+	if nhc.Spec.PrometheusQuery != nil {
+		// run the query regularly
+		interval := getPrometheusInterval(nhc.Spec.PrometheusQuery)
+		updateNextCheck(&interval)
+	}
+	now := currentTime()
+	for _, node := range nodes {
+		isHealthy, expiresIn := r.isHealthy(nhc, &node)
+		updateNextCheck(expiresIn)
+		isLeaseStale, leaseExpiresIn := isLeaseStale(nhc, &node, signals.leases)
+		updateNextCheck(leaseExpiresIn)
+		hasStartupTimedOut, startupExpiresIn := r.hasStartupTimedOut(nhc, &node)
+		updateNextCheck(startupExpiresIn)
+		hasNotReadyPod, podExpiresIn := r.hasNotReadyPod(nhc, &node, signals.notReadyPods)
+		updateNextCheck(podExpiresIn)
+		hasUnhealthyTaint, taintExpiresIn := r.hasUnhealthyTaint(nhc, &node)
+		updateNextCheck(taintExpiresIn)
+		hasUnhealthySignal, signalExpiresIn := r.hasUnhealthySignal(nhc, &node, signals.unhealthySignals)
+		updateNextCheck(signalExpiresIn)
+		hasPrometheusBreach, breachExpiresIn := r.hasPrometheusBreach(nhc, &node, signals.prometheusBreaches)
+		updateNextCheck(breachExpiresIn)
+		if isHealthy && !isLeaseStale && !hasStartupTimedOut && !hasNotReadyPod && !hasUnhealthyTaint && !hasUnhealthySignal && !hasPrometheusBreach && !r.matchesUnhealthyExpression(unhealthyExpression, &node, nhc) {
+			healthy = append(healthy, node)
+		} else if r.MHCChecker.NeedIgnoreNode(&node) {
+			// consider terminating nodes being handled by MHC as healthy, from NHC point of view
+			healthy = append(healthy, node)
+		} else if excluded, until := r.getNodeExclusion(&node, now); excluded {
+			// excluded nodes aren't remediated, consider them as healthy from NHC point of view,
+			// and check back when the exclusion expires
+			healthy = append(healthy, node)
+			if until != nil {
+				updateNextCheck(pointer.Duration(until.Sub(now)))
+			}
+		} else {
+			unhealthy = append(unhealthy, node)
+		}
+	}
+	return
+}
+
+// getNodeExclusion checks if the given node is excluded from remediation by annotation, and until when
+func (r *NodeHealthCheckReconciler) getNodeExclusion(node *v1.Node, now time.Time) (bool, *metav1.Time) {
+	value, exists := node.Annotations[remediationv1alpha1.NodeExcludeAnnotationKey]
+	if !exists {
+		return false, nil
+	}
+	if value == "" {
+		return true, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// better be safe and respect the intention to exclude the node
+		r.Log.Error(err, "invalid expiry of node exclusion, excluding node without expiry", "node", node.Name, "value", value)
+		return true, nil
+	}
+	if !now.Before(until) {
+		return false, nil
+	}
+	return true, &metav1.Time{Time: until}
+}
+
+// updateExcludedNodes updates the list of excluded nodes in the status, and emits an event for newly excluded nodes
+func (r *NodeHealthCheckReconciler) updateExcludedNodes(nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node) {
+	now := currentTime()
+	var excludedNodes []remediationv1alpha1.ExcludedNode
+	for i := range nodes {
+		if excluded, until := r.getNodeExclusion(&nodes[i], now); excluded {
+			excludedNodes = append(excludedNodes, remediationv1alpha1.ExcludedNode{Name: nodes[i].Name, Until: until})
+		}
+	}
+	sort.Slice(excludedNodes, func(i, j int) bool {
+		return excludedNodes[i].Name < excludedNodes[j].Name
+	})
+
+	for _, excludedNode := range excludedNodes {
+		wasExcluded := false
+		for _, oldExcludedNode := range nhc.Status.ExcludedNodes {
+			if oldExcludedNode.Name == excludedNode.Name {
+				wasExcluded = true
+				break
+			}
+		}
+		if !wasExcluded {
+			utils.GetLogWithNHC(r.Log, nhc).Info("node is excluded from remediation", "node", excludedNode.Name)
+			r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonNodeExcluded, "Node %s is excluded from remediation by annotation", excludedNode.Name)
+		}
+	}
+	nhc.Status.ExcludedNodes = excludedNodes
+}
+
+// handleNodeOverrides handles the manual override annotation of the given nodes, and removes it afterwards.
+// It returns the names of the nodes which were reevaluated or whose remediation was restarted.
+func (r *NodeHealthCheckReconciler) handleNodeOverrides(ctx context.Context, nhc *remediationv1alpha1.NodeHealthCheck, nodes []v1.Node, rm resources.Manager) (map[string]struct{}, error) {
+	log := utils.GetLogWithNHC(r.Log, nhc)
+
+	overriddenNodes := make(map[string]struct{})
+	for i := range nodes {
+		node := &nodes[i]
+		override, exists := node.Annotations[remediationv1alpha1.NodeOverrideAnnotationKey]
+		if !exists {
+			continue
+		}
+		switch override {
+		case remediationv1alpha1.NodeOverrideReevaluate:
+			log.Info("reevaluating node because of override annotation", "node", node.Name)
+			r.forgetNode(nhc, node.Name)
+			resources.UpdateStatusNodeReevaluated(node.Name, nhc)
+			overriddenNodes[node.Name] = struct{}{}
+			r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonNodeOverride, "Reevaluating node %s because of the override annotation", node.Name)
+		case remediationv1alpha1.NodeOverrideRestartEscalation:
+			log.Info("restarting remediation because of override annotation", "node", node.Name)
+			if err := r.removeRemediationCRs(nhc, node.Name, false, "of the override annotation", rm); err != nil {
+				return nil, err
+			}
+			overriddenNodes[node.Name] = struct{}{}
+			resources.UpdateStatusNodeRemoved(node.Name, nhc)
+			r.forgetNode(nhc, node.Name)
+			r.Recorder.Eventf(nhc, eventTypeNormal, eventReasonNodeOverride, "Restarting remediation of node %s because of the override annotation", node.Name)
+		default:
+			r.Recorder.Eventf(nhc, eventTypeWarning, eventReasonNodeOverride, "Ignoring unknown override %q of node %s", override, node.Name)
+		}
+
+		// overrides are handled only once
+		patch := client.MergeFrom(node.DeepCopy())
+		delete(node.Annotations, remediationv1alpha1.NodeOverrideAnnotationKey)
+		if err := r.Patch(ctx, node, patch); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove override annotation from node %s", node.Name)
+		}
+	}
+	return overriddenNodes, nil
+}
+
+// isHealthy checks the node's conditions against the NHC's unhealthy conditions.
+// When the node is healthy but has absent conditions which will make it unhealthy later on, the duration until
+// that happens is returned as well.
+func (r *NodeHealthCheckReconciler) isHealthy(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (bool, *time.Duration) {
+	nodeConditionByType := make(map[v1.NodeConditionType]v1.NodeCondition)
+	for _, nc := range node.Status.Conditions {
+		nodeConditionByType[nc.Type] = nc
+	}
+
+	healthy := true
+	var expiresIn *time.Duration
+	now := currentTime()
+	for _, c := range nhc.Spec.UnhealthyConditions {
+		n, exists := nodeConditionByType[c.Type]
+		if !exists {
+			if c.AbsentPolicy != remediationv1alpha1.AbsentConditionPolicyUnhealthy {
+				continue
+			}
+			remaining := r.getAbsentConditionRemaining(nhc, node, c, now)
+			if remaining <= 0 {
+				healthy = false
+			} else if expiresIn == nil || remaining < *expiresIn {
+				expiresIn = &remaining
+			}
+			continue
+		}
+		r.forgetFirstSeen(absentConditionKey(nhc, node, c))
+		if n.Status == c.Status && now.After(n.LastTransitionTime.Add(c.Duration.Duration)) {
+			healthy = false
+		}
+	}
+	if !healthy {
+		return false, nil
+	}
+	return true, expiresIn
+}
+
+// isLeaseStale checks if the node's lease wasn't renewed for the NHC's StaleLeaseDuration.
+// When the lease isn't stale yet, the duration until it will be stale is returned as well.
+func isLeaseStale(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, leases map[string]coordinationv1.Lease) (bool, *time.Duration) {
+	if nhc.Spec.StaleLeaseDuration == nil {
+		return false, nil
+	}
+	lease, exists := leases[node.Name]
+	if !exists || lease.Spec.RenewTime == nil {
+		return false, nil
+	}
+	remaining := lease.Spec.RenewTime.Add(nhc.Spec.StaleLeaseDuration.Duration).Sub(currentTime())
+	if remaining <= 0 {
+		return true, nil
+	}
+	return false, &remaining
+}
+
+// hasStartupTimedOut checks if the node didn't report Ready=True within the NHC's NodeStartupTimeout after its creation.
+// When the timeout didn't expire yet, the duration until it expires is returned as well.
+func (r *NodeHealthCheckReconciler) hasStartupTimedOut(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (bool, *time.Duration) {
+	if nhc.Spec.NodeStartupTimeout == nil {
+		return false, nil
+	}
+	startupDeadline := node.CreationTimestamp.Add(nhc.Spec.NodeStartupTimeout.Duration)
+	for _, c := range node.Status.Conditions {
+		if c.Type != v1.NodeReady {
+			continue
+		}
+		if c.Status == v1.ConditionTrue || c.LastTransitionTime.After(startupDeadline) {
+			// the node started, or its readiness changed after startup, which is covered by the unhealthy conditions
+			return false, nil
+		}
+	}
+	remaining := startupDeadline.Sub(currentTime())
+	if remaining <= 0 {
+		utils.GetLogWithNHC(r.Log, nhc).Info("node is unhealthy because it didn't become ready within the node startup timeout", "node", node.Name)
+		return true, nil
+	}
+	return false, &remaining
+}
+
+// hasNotReadyPod checks if a pod selected by the NHC's unhealthyPods isn't ready on the node for its configured
+// duration. When pods aren't ready for a shorter time, the duration until the node will be unhealthy is returned as well.
+func (r *NodeHealthCheckReconciler) hasNotReadyPod(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, notReadyPods map[string][]notReadyPod) (bool, *time.Duration) {
+	var expiresIn *time.Duration
+	now := currentTime()
+	for _, pod := range notReadyPods[node.Name] {
+		remaining := pod.since.Add(pod.duration).Sub(now)
+		if remaining <= 0 {
+			utils.GetLogWithNHC(r.Log, nhc).Info("node is unhealthy because of not ready pod", "node", node.Name, "pod", pod.name)
+			return true, nil
+		}
+		if expiresIn == nil || remaining < *expiresIn {
+			expiresIn = &remaining
+		}
+	}
+	return false, expiresIn
+}
+
+// hasUnhealthySignal checks if the node has not expired NodeHealthSignals selected by the NHC's unhealthySignals.
+// If so, the duration until all of them are expired is returned as well, for checking back when the node might be
+// healthy again.
+func (r *NodeHealthCheckReconciler) hasUnhealthySignal(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, unhealthySignals map[string][]remediationv1alpha1.NodeHealthSignal) (bool, *time.Duration) {
+	signals := unhealthySignals[node.Name]
+	if len(signals) == 0 {
+		return false, nil
+	}
+	var expiresIn time.Duration
+	now := currentTime()
+	for _, signal := range signals {
+		utils.GetLogWithNHC(r.Log, nhc).Info("node is unhealthy because of node health signal", "node", node.Name, "signal", signal.Name, "source", signal.Spec.Source, "message", signal.Spec.Message)
+		if remaining := signal.Spec.ExpiresAt.Sub(now); remaining > expiresIn {
+			expiresIn = remaining
+		}
+	}
+	return true, &expiresIn
+}
+
+// hasPrometheusBreach checks if the node's prometheus query result breaches the threshold for the configured duration.
+// When it breaches for a shorter time, the duration until the node will be unhealthy is returned as well.
+func (r *NodeHealthCheckReconciler) hasPrometheusBreach(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, breaches map[string]bool) (bool, *time.Duration) {
+	if nhc.Spec.PrometheusQuery == nil || breaches == nil {
+		// no query, or the query failed
+		return false, nil
+	}
+	key := fmt.Sprintf("prometheus/%s/%s", nhc.Name, node.Name)
+	if !breaches[node.Name] {
+		r.forgetFirstSeen(key)
+		return false, nil
+	}
+	now := currentTime()
+	since := r.getFirstSeen(key, node, now)
+	remaining := since.Add(nhc.Spec.PrometheusQuery.Duration.Duration).Sub(now)
+	if remaining <= 0 {
+		return true, nil
+	}
+	return false, &remaining
+}
+
+// getAbsentConditionRemaining returns how long the given condition may still be absent, before the node is
+// considered unhealthy
+func (r *NodeHealthCheckReconciler) getAbsentConditionRemaining(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, c remediationv1alpha1.UnhealthyCondition, now time.Time) time.Duration {
+	absentSince := r.getFirstSeen(absentConditionKey(nhc, node, c), node, now)
+	absentDuration := c.Duration.Duration
+	if c.AbsentDuration != nil {
+		absentDuration = c.AbsentDuration.Duration
+	}
+	return absentSince.Add(absentDuration).Sub(now)
+}
+
+func absentConditionKey(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, c remediationv1alpha1.UnhealthyCondition) string {
+	return fmt.Sprintf("absent/%s/%s/%s", nhc.Name, node.Name, c.Type)
+}
+
+// hasUnhealthyTaint checks if the node has a taint matching the NHC's unhealthy taints for the configured duration.
+// When matching taints exist for a shorter time, the duration until the node will be unhealthy is returned as well.
+func (r *NodeHealthCheckReconciler) hasUnhealthyTaint(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node) (bool, *time.Duration) {
+	unhealthy := false
+	var expiresIn *time.Duration
+	now := currentTime()
+	for _, unhealthyTaint := range nhc.Spec.UnhealthyTaints {
+		key := unhealthyTaintKey(nhc, node, unhealthyTaint)
+		taint := findMatchingTaint(unhealthyTaint, node.Spec.Taints)
+		if taint == nil {
+			r.forgetFirstSeen(key)
+			continue
+		}
+		var since time.Time
+		if taint.TimeAdded != nil {
+			since = taint.TimeAdded.Time
+		} else {
+			since = r.getFirstSeen(key, node, now)
+		}
+		remaining := since.Add(unhealthyTaint.Duration.Duration).Sub(now)
+		if remaining <= 0 {
+			unhealthy = true
+		} else if expiresIn == nil || remaining < *expiresIn {
+			expiresIn = &remaining
+		}
+	}
+	if unhealthy {
+		return true, nil
+	}
+	return false, expiresIn
+}
+
+func findMatchingTaint(unhealthyTaint remediationv1alpha1.UnhealthyTaint, taints []v1.Taint) *v1.Taint {
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Key != unhealthyTaint.Key || taint.Effect != unhealthyTaint.Effect {
+			continue
+		}
+		if unhealthyTaint.Value != "" && taint.Value != unhealthyTaint.Value {
+			continue
+		}
+		return taint
+	}
+	return nil
+}
+
+func unhealthyTaintKey(nhc *remediationv1alpha1.NodeHealthCheck, node *v1.Node, t remediationv1alpha1.UnhealthyTaint) string {
+	return fmt.Sprintf("taint/%s/%s/%s=%s:%s", nhc.Name, node.Name, t.Key, t.Value, t.Effect)
+}
+
+// getFirstSeen returns since when the given key was observed on the given node, which is now on first call.
+// This is used for durations of state which has no timestamp, e.g. absent conditions.
+// Note that this is kept in memory only, so durations restart when the operator restarts.
+func (r *NodeHealthCheckReconciler) getFirstSeen(key string, node *v1.Node, now time.Time) time.Time {
+	r.firstSeenLock.Lock()
+	defer r.firstSeenLock.Unlock()
+
+	firstSeen, exists := r.firstSeen[key]
+	if !exists {
+		firstSeen = now
+		r.firstSeen[key] = firstSeen
+	}
+	// a node which was recreated with the same name can't have the observed state for longer than it exists
+	if node.CreationTimestamp.Time.After(firstSeen) {
+		firstSeen = node.CreationTimestamp.Time
+		r.firstSeen[key] = firstSeen
+	}
+	return firstSeen
+}
+
+func (r *NodeHealthCheckReconciler) forgetFirstSeen(key string) {
+	r.firstSeenLock.Lock()
+	defer r.firstSeenLock.Unlock()
+	delete(r.firstSeen, key)
+}
+
+// forgetNode removes all observations of the given node by the given NHC
+func (r *NodeHealthCheckReconciler) forgetNode(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) {
+	r.firstSeenLock.Lock()
+	defer r.firstSeenLock.Unlock()
+	for key := range r.firstSeen {
+		// keys are formatted as <kind>/<nhc name>/<node name>[/<details>]
+		parts := strings.SplitN(key, "/", 4)
+		if len(parts) >= 3 && parts[1] == nhc.Name && parts[2] == nodeName {
+			delete(r.firstSeen, key)
+		}
+	}
+}
+
+// compileUnhealthyExpression returns nil if there is no expression, or if it can't be compiled
+func (r *NodeHealthCheckReconciler) compileUnhealthyExpression(nhc *remediationv1alpha1.NodeHealthCheck) cel.Program {
+	if nhc.Spec.UnhealthyExpression == "" {
+		return nil
+	}
+	program, err := expression.Compile(nhc.Spec.UnhealthyExpression)
+	if err != nil {
+		// should have been prevented by the webhook
+		utils.GetLogWithNHC(r.Log, nhc).Error(err, "failed to compile unhealthy expression, ignoring it", "expression", nhc.Spec.UnhealthyExpression)
+		return nil
+	}
+	return program
+}
+
+func (r *NodeHealthCheckReconciler) matchesUnhealthyExpression(unhealthyExpression cel.Program, node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck) bool {
+	if unhealthyExpression == nil {
+		return false
+	}
+	matches, err := expression.Matches(unhealthyExpression, node, currentTime())
+	if err != nil {
+		// e.g. missing map keys, consider the node healthy
+		utils.GetLogWithNHC(r.Log, nhc).Error(err, "failed to evaluate unhealthy expression", "node", node.Name)
+		return false
+	}
+	return matches
+}
+
+func (r *NodeHealthCheckReconciler) remediate(node *v1.Node, nhc *remediationv1alpha1.NodeHealthCheck, rm resources.Manager) (*time.Duration, error) {
+
+	log := utils.GetLogWithNHC(r.Log, nhc)
+
+	// prevent remediation of more than 1 control plane node at a time!
+	isControlPlaneNode := utils.IsControlPlane(node)
+	if isControlPlaneNode {
+		if isAllowed, err := r.isControlPlaneRemediationAllowed(node, nhc, rm); err != nil {
+			return nil, errors.Wrapf(err, "failed to check if control plane remediation is allowed")
+		} else if !isAllowed {
+			log.Info("skipping remediation for preventing control plane quorum loss", "node", node.GetName())
+			r.Recorder.Event(nhc, eventTypeWarning, eventReasonRemediationSkipped, fmt.Sprintf("skipping remediation of %s for preventing control plane quorum loss", node.GetName()))
+			return nil, nil
 		}
 	}
 
@@ -443,9 +2291,32 @@ func (r *NodeHealthCheckReconciler) remediate(node *v1.Node, nhc *remediationv1a
 		remediationCR.SetLabels(labels)
 	}
 
+	// new remediations need to fit into the cluster-wide remediation budget
+	isStarted := resources.FindStatusRemediation(node, nhc, func(r *remediationv1alpha1.Remediation) bool {
+		return r.Resource.GroupVersionKind() == remediationCR.GroupVersionKind()
+	}) != nil
+	// new remediation CRs, including the ones of escalating remediations, need to fit into the rate limit
+	if !isStarted && getRemediationRateLimitBudget(nhc) == 0 {
+		return nil, errRemediationRateLimitExceeded
+	}
+	if !isStarted {
+		if err := rm.ReserveRemediationBudget(nhc, node.Name, currentTemplate.GetKind()); err != nil {
+			if _, ok := err.(resources.RemediationBudgetExhaustedError); ok {
+				return nil, err
+			}
+			return nil, errors.Wrapf(err, "failed to reserve remediation budget")
+		}
+	}
+
 	// create remediation CR
 	created, err := rm.CreateRemediationCR(remediationCR, nhc)
 	if err != nil {
+		if !isStarted {
+			// don't block other remediations by the reservation of a remediation which wasn't started
+			if releaseErr := rm.ReleaseRemediationBudget(nhc, node.Name); releaseErr != nil {
+				log.Error(releaseErr, "failed to release remediation budget", "node", node.Name)
+			}
+		}
 		if _, ok := err.(resources.RemediationCRNotOwned); ok {
 			// CR exists but not owned by us, nothing to do
 			return nil, nil
@@ -457,6 +2328,7 @@ func (r *NodeHealthCheckReconciler) remediate(node *v1.Node, nhc *remediationv1a
 	resources.UpdateStatusRemediationStarted(node, nhc, remediationCR)
 
 	if created {
+		recordRateLimitedRemediation(nhc)
 		r.Recorder.Event(nhc, eventTypeNormal, eventReasonRemediationCreated, fmt.Sprintf("Created remediation object for node %s", node.Name))
 		var requeueIn *time.Duration
 		if timeout != nil {
@@ -497,7 +2369,15 @@ func (r *NodeHealthCheckReconciler) remediate(node *v1.Node, nhc *remediationv1a
 
 	now := metav1.Time{Time: currentTime()}
 	timeoutAt := getTimeoutAt(remediationCR, startedRemediation, timeout, log)
-	if !now.After(timeoutAt) {
+	// flapping nodes are escalated immediately, when their remediation started before they were flapping
+	escalateFlapping := false
+	if getFlappingAction(nhc) == remediationv1alpha1.FlappingActionEscalate {
+		if status := resources.FindStatusUnhealthyNode(node.Name, nhc); status != nil && status.FlappingSince != nil && startedRemediation.Started.Before(status.FlappingSince) {
+			log.Info("escalating remediation of flapping node", "node", node.Name)
+			escalateFlapping = true
+		}
+	}
+	if !now.After(timeoutAt) && !escalateFlapping {
 		// not timed out yet, come back when we do so
 		return pointer.Duration(timeoutAt.Sub(now.Time)), nil
 	}
@@ -544,12 +2424,21 @@ func (r *NodeHealthCheckReconciler) patchStatus(nhc, nhcOrig *remediationv1alpha
 
 	// calculate phase and reason
 	disabledCondition := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeDisabled)
-	if disabledCondition != nil && disabledCondition.Status == metav1.ConditionTrue {
+	if nhc.DeletionTimestamp != nil {
+		nhc.Status.Phase = remediationv1alpha1.PhaseTerminating
+		nhc.Status.Reason = fmt.Sprintf("NHC is being deleted with deletion policy %s", getDeletionPolicy(nhc))
+	} else if disabledCondition != nil && disabledCondition.Status == metav1.ConditionTrue {
 		nhc.Status.Phase = remediationv1alpha1.PhaseDisabled
 		nhc.Status.Reason = fmt.Sprintf("NHC is disabled: %s: %s", disabledCondition.Reason, disabledCondition.Message)
 	} else if len(nhc.Spec.PauseRequests) > 0 {
 		nhc.Status.Phase = remediationv1alpha1.PhasePaused
 		nhc.Status.Reason = fmt.Sprintf("NHC is paused: %s", strings.Join(nhc.Spec.PauseRequests, ","))
+	} else if isStormDetected(nhc) {
+		nhc.Status.Phase = remediationv1alpha1.PhasePaused
+		nhc.Status.Reason = "NHC is paused: storm detected"
+		if stormCondition := meta.FindStatusCondition(nhc.Status.Conditions, remediationv1alpha1.ConditionTypeStormDetected); stormCondition != nil {
+			nhc.Status.Reason = fmt.Sprintf("NHC is paused: storm detected: %s", stormCondition.Message)
+		}
 	} else if len(nhc.Status.InFlightRemediations) > 0 {
 		nhc.Status.Phase = remediationv1alpha1.PhaseRemediating
 		nhc.Status.Reason = fmt.Sprintf("NHC is remediating %v nodes", len(nhc.Status.InFlightRemediations))
@@ -635,6 +2524,36 @@ func (r *NodeHealthCheckReconciler) addWatch(remediationCR *unstructured.Unstruc
 	return nil
 }
 
+// addPodWatch starts watching pods, which is only needed when an NHC configures unhealthyPods, so that the
+// pods of the whole cluster aren't cached otherwise
+func (r *NodeHealthCheckReconciler) addPodWatch() error {
+	r.watchesLock.Lock()
+	defer r.watchesLock.Unlock()
+
+	key := v1.SchemeGroupVersion.WithKind("Pod").String()
+	if _, exists := r.watches[key]; exists {
+		// already watching
+		return nil
+	}
+	if err := r.ctrl.Watch(
+		&source.Kind{Type: &v1.Pod{}},
+		handler.EnqueueRequestsFromMapFunc(utils.NHCByPodMapperFunc(r.Client, r.Log)),
+		predicate.Funcs{
+			// pods becoming unready for too long are handled by requeuing, only readiness changes are interesting
+			UpdateFunc: func(ev event.UpdateEvent) bool { return podUpdateNeedsReconcile(ev) },
+			// new pods might never become ready, deleted unready pods might make their node healthy again,
+			// the mapper only enqueues NHCs selecting the pod
+			CreateFunc:  func(_ event.CreateEvent) bool { return true },
+			DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+			GenericFunc: func(_ event.GenericEvent) bool { return false },
+		},
+	); err != nil {
+		return err
+	}
+	r.watches[key] = struct{}{}
+	return nil
+}
+
 func getTimeoutAt(remediationCR *unstructured.Unstructured, remediation *remediationv1alpha1.Remediation, configuredTimeout *time.Duration, log logr.Logger) time.Time {
 	// We have 2 ways to time out:
 	// - after the configured timeout
@@ -3,11 +3,15 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,13 +19,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 
 	"github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+	"github.com/medik8s/node-healthcheck-operator/controllers/resources"
 	"github.com/medik8s/node-healthcheck-operator/controllers/utils"
 )
 
@@ -49,6 +56,7 @@ var _ = Describe("Node Health Check CR", func() {
 		AfterEach(func() {
 			err := k8sClient.Delete(context.Background(), underTest)
 			Expect(err).NotTo(HaveOccurred())
+			ensureNHCDeleted(underTest)
 		})
 
 		When("creating a resource", func() {
@@ -102,6 +110,7 @@ var _ = Describe("Node Health Check CR", func() {
 
 		AfterEach(func() {
 			_ = k8sClient.Delete(context.Background(), underTest)
+			ensureNHCDeleted(underTest)
 		})
 
 		When("specifying an external remediation template", func() {
@@ -150,6 +159,12 @@ var _ = Describe("Node Health Check CR", func() {
 			// ignore errors, CRs might be deleted by reconcile
 			_ = k8sClient.Delete(context.Background(), obj)
 		}
+		// NHCs are only gone after the controller removed the finalizer
+		for _, obj := range objects {
+			if nhc, isNHC := obj.(*v1alpha1.NodeHealthCheck); isNHC {
+				ensureNHCDeleted(nhc)
+			}
+		}
 	}
 
 	Context("Reconciliation", func() {
@@ -307,6 +322,403 @@ var _ = Describe("Node Health Check CR", func() {
 
 			})
 
+			When("nodes are unhealthy according to the unhealthy expression", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+					// conditions which never match, in order to test the expression only
+					underTest.Spec.UnhealthyConditions = []v1alpha1.UnhealthyCondition{
+						{
+							Type:     "Foo",
+							Status:   v1.ConditionTrue,
+							Duration: metav1.Duration{Duration: time.Minute},
+						},
+					}
+					underTest.Spec.UnhealthyExpression = `conditions.exists(c, c.type == "Ready" && c.status == "False" && now - c.lastTransitionTime > duration("5m"))`
+				})
+
+				It("creates a remediation CR for the unhealthy node", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					cr = newRemediationCR("healthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					Expect(underTest.Status.HealthyNodes).To(Equal(2))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+				})
+			})
+
+			When("nodes miss a condition with absent policy Unhealthy", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+					underTest.Spec.UnhealthyConditions = append(underTest.Spec.UnhealthyConditions, v1alpha1.UnhealthyCondition{
+						Type:           "KernelDeadlock",
+						Status:         v1.ConditionTrue,
+						Duration:       metav1.Duration{Duration: time.Minute},
+						AbsentPolicy:   v1alpha1.AbsentConditionPolicyUnhealthy,
+						AbsentDuration: &metav1.Duration{Duration: 3 * time.Second},
+					})
+				})
+
+				It("considers nodes unhealthy after the absent duration", func() {
+					// absent duration not expired yet
+					Expect(underTest.Status.HealthyNodes).To(Equal(2))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+
+					// reconcile is requeued when absent duration expires
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.HealthyNodes).To(Equal(0))
+					}, "5s", "500ms").Should(Succeed())
+				})
+			})
+
+			When("a node doesn't become ready within the node startup timeout", func() {
+				BeforeEach(func() {
+					setupObjects(0, 4)
+					underTest.Spec.NodeStartupTimeout = &metav1.Duration{Duration: time.Second}
+					startingNode := newNode("starting-worker-node", v1.NodeReady, v1.ConditionFalse, 0, false).(*v1.Node)
+					startingNode.Status.Conditions = nil
+					objects = append(objects, startingNode)
+				})
+
+				It("creates a remediation CR for the node", func() {
+					cr := newRemediationCR("starting-worker-node", underTest)
+					Eventually(func() error {
+						return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					}, "5s", "250ms").Should(Succeed())
+
+					// healthy nodes aren't affected
+					cr = newRemediationCR("healthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			Context("health sources", func() {
+				BeforeEach(func() {
+					setupObjects(1, 4)
+				})
+
+				updateSpec := func(update func(nhc *v1alpha1.NodeHealthCheck)) {
+					Eventually(func() error {
+						if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest); err != nil {
+							return err
+						}
+						update(underTest)
+						return k8sClient.Update(context.Background(), underTest)
+					}, "5s", "250ms").Should(Succeed())
+				}
+
+				setTaint := func(nodeName string, taint *v1.Taint) {
+					Eventually(func() error {
+						node := &v1.Node{}
+						if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: nodeName}, node); err != nil {
+							return err
+						}
+						node.Spec.Taints = nil
+						if taint != nil {
+							node.Spec.Taints = []v1.Taint{*taint}
+						}
+						return k8sClient.Update(context.Background(), node)
+					}, "5s", "250ms").Should(Succeed())
+				}
+
+				withStaleLease := func() {
+					leases := []client.Object{
+						newNodeLease("healthy-worker-node-1", time.Now().Add(-2*time.Minute)),
+						newNodeLease("healthy-worker-node-2", time.Now()),
+					}
+					createObjects(leases...)
+					objects = append(objects, leases...)
+					updateSpec(func(nhc *v1alpha1.NodeHealthCheck) {
+						nhc.Spec.StaleLeaseDuration = &metav1.Duration{Duration: time.Minute}
+					})
+				}
+
+				withNotReadyPod := func() {
+					notReadyPod := newPod("cni-1", "healthy-worker-node-1", v1.ConditionFalse, time.Now().Add(-10*time.Minute))
+					readyPod := newPod("cni-2", "healthy-worker-node-2", v1.ConditionTrue, time.Now().Add(-10*time.Minute))
+					recentlyNotReadyPod := newPod("cni-3", "healthy-worker-node-3", v1.ConditionFalse, time.Now())
+					for _, pod := range []*v1.Pod{notReadyPod, readyPod, recentlyNotReadyPod} {
+						status := pod.Status
+						Expect(k8sClient.Create(context.Background(), pod)).To(Succeed())
+						pod.Status = status
+						Expect(k8sClient.Status().Update(context.Background(), pod)).To(Succeed())
+						DeferCleanup(k8sClient.Delete, context.Background(), pod, client.GracePeriodSeconds(0))
+					}
+					updateSpec(func(nhc *v1alpha1.NodeHealthCheck) {
+						nhc.Spec.UnhealthyPods = []v1alpha1.UnhealthyPod{
+							{
+								Namespace: "default",
+								Selector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"app": "cni"},
+								},
+								Duration: metav1.Duration{Duration: 5 * time.Minute},
+							},
+						}
+					})
+				}
+
+				withUnhealthyTaint := func() {
+					setTaint("healthy-worker-node-1", &v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}})
+					setTaint("healthy-worker-node-2", &v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute, TimeAdded: &metav1.Time{Time: time.Now()}})
+					// without timeAdded, the duration starts when NHC sees the taint
+					setTaint("healthy-worker-node-3", &v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute})
+					updateSpec(func(nhc *v1alpha1.NodeHealthCheck) {
+						nhc.Spec.UnhealthyTaints = []v1alpha1.UnhealthyTaint{
+							{
+								Key:      v1.TaintNodeUnreachable,
+								Effect:   v1.TaintEffectNoExecute,
+								Duration: metav1.Duration{Duration: 5 * time.Minute},
+							},
+						}
+					})
+				}
+
+				withUnhealthySignal := func() {
+					signals := []client.Object{
+						newNodeHealthSignal("signal-1", "healthy-worker-node-1", "disk-smart", v1alpha1.SignalSeverityCritical, time.Now().Add(time.Hour)),
+						// expired
+						newNodeHealthSignal("signal-2", "healthy-worker-node-2", "disk-smart", v1alpha1.SignalSeverityCritical, time.Now().Add(-time.Minute)),
+						// severity too low
+						newNodeHealthSignal("signal-3", "healthy-worker-node-3", "disk-smart", v1alpha1.SignalSeverityInfo, time.Now().Add(time.Hour)),
+						// other source
+						newNodeHealthSignal("signal-4", "healthy-worker-node-4", "network", v1alpha1.SignalSeverityCritical, time.Now().Add(time.Hour)),
+					}
+					createObjects(signals...)
+					objects = append(objects, signals...)
+					updateSpec(func(nhc *v1alpha1.NodeHealthCheck) {
+						nhc.Spec.UnhealthySignals = []v1alpha1.UnhealthySignal{
+							{
+								Source:      "disk-smart",
+								MinSeverity: v1alpha1.SignalSeverityWarning,
+							},
+						}
+					})
+				}
+
+				withPrometheusBreach := func() {
+					prometheusServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						_, _ = fmt.Fprint(w, `{"status": "success", "data": {"resultType": "vector", "result": [
+							{"metric": {"instance": "healthy-worker-node-1"}, "value": [1680000000.123, "0.95"]},
+							{"metric": {"instance": "healthy-worker-node-2"}, "value": [1680000000.123, "0.5"]}
+						]}}`)
+					}))
+					DeferCleanup(prometheusServer.Close)
+					updateSpec(func(nhc *v1alpha1.NodeHealthCheck) {
+						nhc.Spec.PrometheusQuery = &v1alpha1.PrometheusQuery{
+							URL:       prometheusServer.URL,
+							Query:     "node_disk_io_time_seconds_total",
+							NodeLabel: "instance",
+							Operator:  v1alpha1.ThresholdOperatorGreaterThan,
+							Threshold: "0.9",
+							Duration:  metav1.Duration{Duration: 0},
+						}
+					})
+				}
+
+				expectRemediation := func(nodeName string) {
+					Eventually(func() error {
+						cr := newRemediationCR(nodeName, underTest)
+						return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					}, "5s", "250ms").Should(Succeed())
+				}
+
+				expectNoRemediation := func(nodeName string) {
+					cr := newRemediationCR(nodeName, underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				}
+
+				DescribeTable("creates a remediation CR for the unhealthy node only",
+					func(configure func()) {
+						configure()
+
+						expectRemediation("healthy-worker-node-1")
+						Eventually(func(g Gomega) {
+							g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+							// nodes which aren't covered by a source, or don't breach it yet, are healthy
+							g.Expect(underTest.Status.HealthyNodes).To(Equal(3))
+							g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(2))
+						}, "5s", "250ms").Should(Succeed())
+						for _, nodeName := range []string{"healthy-worker-node-2", "healthy-worker-node-3", "healthy-worker-node-4"} {
+							expectNoRemediation(nodeName)
+						}
+					},
+					Entry("with a stale lease", withStaleLease),
+					Entry("with a not ready pod", withNotReadyPod),
+					Entry("with an unhealthy taint", withUnhealthyTaint),
+					Entry("with an unhealthy signal", withUnhealthySignal),
+					Entry("with a breached prometheus query threshold", withPrometheusBreach),
+					Entry("with several sources firing together", func() {
+						withStaleLease()
+						withUnhealthyTaint()
+						withUnhealthySignal()
+					}),
+				)
+
+				When("one source recovers while another one is still unhealthy", func() {
+					It("keeps remediating the node until all sources are healthy", func() {
+						withStaleLease()
+						withUnhealthyTaint()
+						expectRemediation("healthy-worker-node-1")
+
+						By("renewing the stale lease")
+						lease := &coordinationv1.Lease{}
+						Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: v1.NamespaceNodeLease, Name: "healthy-worker-node-1"}, lease)).To(Succeed())
+						lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+						Expect(k8sClient.Update(context.Background(), lease)).To(Succeed())
+						Consistently(func(g Gomega) {
+							cr := newRemediationCR("healthy-worker-node-1", underTest)
+							g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+							g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+							g.Expect(underTest.Status.HealthyNodes).To(Equal(3))
+						}, "3s", "250ms").Should(Succeed())
+
+						By("removing the unhealthy taint")
+						setTaint("healthy-worker-node-1", nil)
+						Eventually(func(g Gomega) {
+							cr := newRemediationCR("healthy-worker-node-1", underTest)
+							err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+							g.Expect(errors.IsNotFound(err)).To(BeTrue())
+							g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+							g.Expect(underTest.Status.HealthyNodes).To(Equal(4))
+							g.Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						}, "5s", "250ms").Should(Succeed())
+					})
+				})
+			})
+
+			When("the kubelet probe is configured", func() {
+				BeforeEach(func() {
+					setupObjects(2, 4)
+					kubeletServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusOK)
+					}))
+					DeferCleanup(kubeletServer.Close)
+					kubeletAddress := kubeletServer.Listener.Addr().(*net.TCPAddr)
+					// only the kubelet of the first unhealthy node is reachable
+					for _, obj := range objects {
+						if node, isNode := obj.(*v1.Node); isNode && node.Name == "unhealthy-worker-node-1" {
+							node.Status.Addresses = []v1.NodeAddress{
+								{Type: v1.NodeInternalIP, Address: kubeletAddress.IP.String()},
+							}
+						}
+					}
+					underTest.Spec.KubeletProbe = &v1alpha1.KubeletProbe{
+						Port:   pointer.Int32(int32(kubeletAddress.Port)),
+						Path:   "/healthz",
+						Scheme: v1.URISchemeHTTP,
+					}
+				})
+
+				It("defers remediation of the node with responding kubelet", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					cr = newRemediationCR("unhealthy-worker-node-2", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(2))
+					for _, unhealthyNode := range underTest.Status.UnhealthyNodes {
+						Expect(unhealthyNode.KubeletProbe).ToNot(BeNil())
+						if unhealthyNode.Name == "unhealthy-worker-node-1" {
+							Expect(unhealthyNode.KubeletProbe.Result).To(Equal(v1alpha1.KubeletProbeResultResponding))
+							Expect(unhealthyNode.Remediations).To(BeEmpty())
+						} else {
+							Expect(unhealthyNode.KubeletProbe.Result).To(Equal(v1alpha1.KubeletProbeResultNotResponding))
+							Expect(unhealthyNode.Remediations).To(HaveLen(1))
+						}
+					}
+					Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+				})
+			})
+
+			Context("unhealthy node becomes healthy again", func() {
+				setNodeReadyStatus := func(status v1.ConditionStatus) {
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					node.Status.Conditions[0].Status = status
+					Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+					// give the reconciler some time
+					time.Sleep(2 * time.Second)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+				}
+
+				BeforeEach(func() {
+					setupObjects(1, 2)
+				})
+
+				When("the healthy stabilization window is configured", func() {
+					BeforeEach(func() {
+						underTest.Spec.HealthyStabilizationWindow = &metav1.Duration{Duration: time.Hour}
+					})
+
+					It("keeps the remediation CR during the window", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						setNodeReadyStatus(v1.ConditionTrue)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+						Expect(underTest.Status.HealthyNodes).To(Equal(3))
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes[0].HealthySince).ToNot(BeNil())
+						Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+
+						setNodeReadyStatus(v1.ConditionFalse)
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes[0].HealthySince).To(BeNil())
+					})
+				})
+
+				When("the node is flapping", func() {
+					BeforeEach(func() {
+						underTest.Spec.FlappingDetection = &v1alpha1.FlappingDetection{
+							MaxTransitions: 1,
+							Window:         metav1.Duration{Duration: time.Hour},
+							Action:         v1alpha1.FlappingActionStopRemediation,
+						}
+					})
+
+					It("stops remediation", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						// first transition: remediation is stopped, but the transition is tracked
+						setNodeReadyStatus(v1.ConditionTrue)
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes[0].HealthyTransitions).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(BeEmpty())
+						Expect(underTest.Status.UnhealthyNodes[0].FlappingSince).To(BeNil())
+
+						// node isn't flapping yet, so it is remediated again
+						setNodeReadyStatus(v1.ConditionFalse)
+						cr = newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						// second transition: node is flapping
+						setNodeReadyStatus(v1.ConditionTrue)
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.UnhealthyNodes[0].HealthyTransitions).To(HaveLen(2))
+						Expect(underTest.Status.UnhealthyNodes[0].FlappingSince).ToNot(BeNil())
+
+						// flapping node isn't remediated anymore
+						setNodeReadyStatus(v1.ConditionFalse)
+						cr = newRemediationCR("unhealthy-worker-node-1", underTest)
+						err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(BeEmpty())
+						Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+					})
+				})
+			})
+
 			When("few nodes are unhealthy and healthy nodes below min healthy", func() {
 				BeforeEach(func() {
 					setupObjects(4, 3)
@@ -323,49 +735,629 @@ var _ = Describe("Node Health Check CR", func() {
 					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
 					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
 					Expect(underTest.Status.Reason).ToNot(BeEmpty())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonMinHealthyNotMet),
+						)))
+				})
+
+			})
+
+			When("the number of unhealthy nodes is outside of the unhealthy range", func() {
+				BeforeEach(func() {
+					underTest.Spec.UnhealthyRange = "[0-1]"
+					setupObjects(2, 5)
+				})
+
+				It("skips remediation and updates status", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonOutsideUnhealthyRange),
+						)))
+				})
+			})
+
+			When("more nodes are unhealthy than max unhealthy", func() {
+				BeforeEach(func() {
+					maxUnhealthy := intstr.FromInt(1)
+					underTest.Spec.MaxUnhealthy = &maxUnhealthy
+					setupObjects(2, 5)
+				})
+
+				It("remediates max unhealthy nodes only and updates status", func() {
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonMaxUnhealthyReached),
+						)))
+				})
+			})
+
+			When("more nodes are unhealthy than the remediation rate limit allows", func() {
+				BeforeEach(func() {
+					underTest.Spec.RemediationRateLimit = &v1alpha1.RemediationRateLimit{
+						MaxRemediations: 1,
+						Window:          metav1.Duration{Duration: time.Hour},
+					}
+					setupObjects(2, 5)
+				})
+
+				It("remediates the allowed number of nodes only and queues the others", func() {
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.RemediationRateLimit).ToNot(BeNil())
+					Expect(underTest.Status.RemediationRateLimit.RecentRemediations).To(HaveLen(1))
+					Expect(underTest.Status.RemediationRateLimit.QueuedNodes).To(HaveLen(1))
+					Expect(underTest.Status.RemediationRateLimit.QueuedNodes).ToNot(ContainElement(underTest.Status.UnhealthyNodes[0].Name))
+					Expect(underTest.Status.RemediationRateLimit.NextRemediationAt).ToNot(BeNil())
+					Expect(underTest.Status.RemediationRateLimit.NextRemediationAt.Time).To(BeTemporally("~",
+						underTest.Status.RemediationRateLimit.RecentRemediations[0].Add(time.Hour), time.Second))
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonRateLimitExceeded),
+						)))
+				})
+			})
+
+			When("more nodes become unhealthy within the storm detection window than allowed", func() {
+				BeforeEach(func() {
+					underTest.Spec.StormDetection = &v1alpha1.StormDetection{
+						MaxNewUnhealthyNodes: intstr.FromInt(1),
+						Window:               metav1.Duration{Duration: time.Hour},
+						StabilizationPeriod:  metav1.Duration{Duration: time.Hour},
+					}
+					setupObjects(1, 4)
+				})
+
+				It("pauses remediation and sets the StormDetected condition", func() {
+					By("not counting nodes which were unhealthy already")
+					Expect(underTest.Status.Storm).ToNot(BeNil())
+					Expect(underTest.Status.Storm.UnhealthyNodes).To(ConsistOf(
+						And(
+							HaveField("Name", "unhealthy-worker-node-1"),
+							HaveField("Since", BeNil()),
+						)))
+					Expect(underTest.Status.Storm.DetectedAt).To(BeNil())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeStormDetected),
+							HaveField("Status", metav1.ConditionFalse),
+							HaveField("Reason", v1alpha1.ConditionReasonNoStorm),
+						)))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+
+					By("making two healthy nodes unhealthy")
+					for _, nodeName := range []string{"healthy-worker-node-1", "healthy-worker-node-2"} {
+						node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+						node.Status.Conditions[0].Status = v1.ConditionFalse
+						node.Status.Conditions[0].LastTransitionTime = metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+						Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+					}
+					time.Sleep(2 * time.Second)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+
+					Expect(underTest.Status.Storm.UnhealthyNodes).To(HaveLen(3))
+					Expect(underTest.Status.Storm.LastUnhealthyTransition).ToNot(BeNil())
+					Expect(underTest.Status.Storm.DetectedAt).ToNot(BeNil())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeStormDetected),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonStormThresholdExceeded),
+						)))
+					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhasePaused))
+					Expect(underTest.Status.Reason).To(ContainSubstring("storm detected"))
+
+					By("not remediating the new unhealthy nodes")
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					cr := newRemediationCR("healthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the cluster-wide remediation budget is exhausted", func() {
+				BeforeEach(func() {
+					setupObjects(2, 5)
+					// create the budget before the NHC
+					budget := newRemediationBudget(pointer.Int(1))
+					objects = append([]client.Object{budget}, objects...)
+				})
+
+				It("remediates nodes within the budget only and updates status", func() {
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Status", metav1.ConditionTrue),
+							HaveField("Reason", v1alpha1.ConditionReasonRemediationBudgetExhausted),
+						)))
+
+					budget := &v1alpha1.RemediationBudget{}
+					Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: v1alpha1.RemediationBudgetName}, budget)).To(Succeed())
+					Expect(budget.Status.Remediations).To(ConsistOf(
+						And(
+							HaveField("NodeHealthCheck", underTest.Name),
+							HaveField("NodeName", underTest.Status.UnhealthyNodes[0].Name),
+							HaveField("TemplateKind", underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Kind+"Template"),
+						)))
+				})
+			})
+
+			When("the remediation budget of the template kind is exhausted", func() {
+				BeforeEach(func() {
+					setupObjects(1, 5)
+					budget := newRemediationBudget(nil)
+					var templateKind string
+					if underTest.Spec.RemediationTemplate != nil {
+						templateKind = underTest.Spec.RemediationTemplate.Kind
+					} else {
+						templateKind = underTest.Spec.EscalatingRemediations[0].RemediationTemplate.Kind
+					}
+					budget.Spec.TemplateKindLimits = []v1alpha1.TemplateKindLimit{
+						{Kind: templateKind, MaxConcurrentRemediations: 0},
+					}
+					objects = append([]client.Object{budget}, objects...)
+				})
+
+				It("doesn't remediate", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Reason", v1alpha1.ConditionReasonRemediationBudgetExhausted),
+						)))
+				})
+			})
+
+			Context("with topology", func() {
+				zoneLabel := "topology.kubernetes.io/zone"
+				setZone := func(zone string, nodeNames ...string) {
+					for _, o := range objects {
+						for _, nodeName := range nodeNames {
+							if node, ok := o.(*v1.Node); ok && node.Name == nodeName {
+								node.Labels[zoneLabel] = zone
+							}
+						}
+					}
+				}
+
+				When("a failure domain doesn't have enough healthy nodes", func() {
+					BeforeEach(func() {
+						underTest.Spec.Topology = &v1alpha1.Topology{Key: zoneLabel}
+						setupObjects(2, 4)
+						setZone("zone-a", "unhealthy-worker-node-1", "healthy-worker-node-1")
+						setZone("zone-b", "unhealthy-worker-node-2", "healthy-worker-node-2", "healthy-worker-node-3", "healthy-worker-node-4")
+					})
+
+					It("remediates nodes of other failure domains only and reports the domains", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						cr = newRemediationCR("unhealthy-worker-node-2", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						Expect(underTest.Status.FailureDomains).To(HaveLen(2))
+						Expect(underTest.Status.FailureDomains[0]).To(And(
+							HaveField("Name", "zone-a"),
+							HaveField("ObservedNodes", 2),
+							HaveField("HealthyNodes", 1),
+							HaveField("MinHealthy", 2),
+							HaveField("RemediatingNodes", 0),
+							HaveField("AvailableRemediations", Equal(pointer.Int(0))),
+						))
+						Expect(underTest.Status.FailureDomains[1]).To(And(
+							HaveField("Name", "zone-b"),
+							HaveField("ObservedNodes", 4),
+							HaveField("HealthyNodes", 3),
+							HaveField("MinHealthy", 3),
+							HaveField("RemediatingNodes", 1),
+							HaveField("AvailableRemediations", BeNil()),
+						))
+						Expect(underTest.Status.Conditions).To(ContainElement(
+							And(
+								HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+								HaveField("Reason", v1alpha1.ConditionReasonMinHealthyNotMet),
+							)))
+					})
+				})
+
+				When("more nodes of a failure domain are unhealthy than max remediations per domain", func() {
+					BeforeEach(func() {
+						underTest.Spec.Topology = &v1alpha1.Topology{Key: zoneLabel, MaxRemediationsPerDomain: pointer.Int(1)}
+						setupObjects(2, 4)
+						setZone("zone-a", "unhealthy-worker-node-1", "unhealthy-worker-node-2",
+							"healthy-worker-node-1", "healthy-worker-node-2", "healthy-worker-node-3", "healthy-worker-node-4")
+					})
+
+					It("remediates one node of the failure domain only", func() {
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+						Expect(underTest.Status.FailureDomains).To(ConsistOf(And(
+							HaveField("Name", "zone-a"),
+							HaveField("RemediatingNodes", 1),
+							HaveField("AvailableRemediations", Equal(pointer.Int(0))),
+						)))
+						Expect(underTest.Status.Conditions).To(ContainElement(
+							And(
+								HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+								HaveField("Status", metav1.ConditionTrue),
+								HaveField("Reason", v1alpha1.ConditionReasonMaxRemediationsPerDomainReached),
+								HaveField("Message", ContainSubstring(`"zone-a" has 1 remediating nodes`)),
+							)))
+					})
+				})
+
+				When("all selected nodes don't have enough healthy nodes", func() {
+					BeforeEach(func() {
+						underTest.Spec.Topology = &v1alpha1.Topology{Key: zoneLabel}
+						setupObjects(2, 2)
+						setZone("zone-a", "unhealthy-worker-node-1", "healthy-worker-node-1", "healthy-worker-node-2")
+						setZone("zone-b", "unhealthy-worker-node-2")
+					})
+
+					It("doesn't remediate nodes of failure domains with enough healthy nodes", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+						Expect(underTest.Status.Conditions).To(ContainElement(
+							And(
+								HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+								HaveField("Reason", v1alpha1.ConditionReasonMinHealthyNotMet),
+								HaveField("Message", ContainSubstring("selected by the selector")),
+							)))
+					})
+				})
+
+				When("nodes don't have the topology label", func() {
+					BeforeEach(func() {
+						underTest.Spec.Topology = &v1alpha1.Topology{Key: zoneLabel}
+						setupObjects(1, 2)
+						setZone("zone-a", "healthy-worker-node-1")
+					})
+
+					It("doesn't add them to a failure domain", func() {
+						Expect(underTest.Status.FailureDomains).To(ConsistOf(And(
+							HaveField("Name", "zone-a"),
+							HaveField("ObservedNodes", 1),
+							HaveField("HealthyNodes", 1),
+						)))
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					})
+				})
+			})
+
+			When("few nodes become healthy", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+					remediationCR := newRemediationCR("healthy-worker-node-2", underTest)
+					remediationCROther := newRemediationCR("healthy-worker-node-1", underTest)
+					refs := remediationCROther.GetOwnerReferences()
+					refs[0].Name = "other"
+					remediationCROther.SetOwnerReferences(refs)
+					objects = append(objects, remediationCR, remediationCROther)
+				})
+
+				It("deletes an existing remediation CR and updates status", func() {
+					cr := newRemediationCR("healthy-worker-node-2", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					// owned by other NHC, should not be deleted
+					cr = newRemediationCR("healthy-worker-node-1", underTest)
+					err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(err).NotTo(HaveOccurred())
+
+					cr = newRemediationCR("unhealthy-worker-node-1", underTest)
+					err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(underTest.Status.HealthyNodes).To(Equal(2))
+					Expect(underTest.Status.ObservedNodes).To(Equal(3))
+					Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Name).To(Equal(cr.GetName()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.GroupVersionKind()).To(Equal(cr.GroupVersionKind()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Name).To(Equal(cr.GetName()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Namespace).To(Equal(cr.GetNamespace()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(cr.GetUID()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Started).ToNot(BeNil())
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).To(BeNil())
+					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
+					Expect(underTest.Status.Reason).ToNot(BeEmpty())
+				})
+			})
+
+			When("a remediated node is deleted", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+				})
+
+				It("deletes the remediation CR and cleans up status", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
+
+					By("deleting the node")
+					node := &v1.Node{}
+					node.Name = "unhealthy-worker-node-1"
+					Expect(k8sClient.Delete(context.Background(), node)).To(Succeed())
+					time.Sleep(2 * time.Second)
+
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					Expect(underTest.Status.ObservedNodes).To(Equal(2))
+					Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
+				})
+
+				It("cleans up the node while the NHC is paused", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					By("pausing the NHC")
+					underTest.Spec.PauseRequests = []string{"test"}
+					Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+
+					By("deleting the node")
+					node := &v1.Node{}
+					node.Name = "unhealthy-worker-node-1"
+					Expect(k8sClient.Delete(context.Background(), node)).To(Succeed())
+
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+						g.Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					}, "5s", "250ms").Should(Succeed())
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("an unhealthy node is excluded by annotation", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+					for _, o := range objects {
+						if o.GetName() == "unhealthy-worker-node-1" {
+							o.SetAnnotations(map[string]string{v1alpha1.NodeExcludeAnnotationKey: ""})
+						}
+					}
+				})
+
+				It("doesn't remediate the node and updates status", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					Expect(underTest.Status.ExcludedNodes).To(ConsistOf(v1alpha1.ExcludedNode{Name: "unhealthy-worker-node-1"}))
+					Expect(underTest.Status.HealthyNodes).To(Equal(3))
+				})
+			})
+
+			When("a remediated node gets excluded by annotation", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+				})
+
+				It("stops the remediation", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					By("adding the exclude annotation")
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					node.Annotations = map[string]string{v1alpha1.NodeExcludeAnnotationKey: ""}
+					Expect(k8sClient.Update(context.Background(), node)).To(Succeed())
+					time.Sleep(2 * time.Second)
+
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+					Expect(underTest.Status.HealthyNodes).To(Equal(3))
+				})
+			})
+
+			When("a remediated node has the restart-escalation override annotation", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
+				})
+
+				It("restarts remediation and removes the annotation", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					oldUID := cr.GetUID()
+
+					By("adding the override annotation")
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					node.Annotations = map[string]string{v1alpha1.NodeOverrideAnnotationKey: v1alpha1.NodeOverrideRestartEscalation}
+					Expect(k8sClient.Update(context.Background(), node)).To(Succeed())
+					time.Sleep(2 * time.Second)
+
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					Expect(node.Annotations).ToNot(HaveKey(v1alpha1.NodeOverrideAnnotationKey))
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetUID()).ToNot(Equal(oldUID))
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(cr.GetUID()))
+				})
+
+				It("keeps the annotation while the NHC is paused", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					oldUID := cr.GetUID()
+
+					By("pausing the NHC")
+					underTest.Spec.PauseRequests = []string{"test"}
+					Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+					time.Sleep(1 * time.Second)
+
+					By("adding the override annotation")
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					node.Annotations = map[string]string{v1alpha1.NodeOverrideAnnotationKey: v1alpha1.NodeOverrideRestartEscalation}
+					Expect(k8sClient.Update(context.Background(), node)).To(Succeed())
+					time.Sleep(2 * time.Second)
+
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					Expect(node.Annotations).To(HaveKey(v1alpha1.NodeOverrideAnnotationKey))
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetUID()).To(Equal(oldUID))
+
+					By("unpausing the NHC")
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					underTest.Spec.PauseRequests = nil
+					Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+					time.Sleep(2 * time.Second)
+
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					Expect(node.Annotations).ToNot(HaveKey(v1alpha1.NodeOverrideAnnotationKey))
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetUID()).ToNot(Equal(oldUID))
+				})
+			})
+
+			When("a remediated node is replaced by a node with the same name", func() {
+				BeforeEach(func() {
+					setupObjects(1, 2)
 				})
 
+				It("restarts remediation for the new node", func() {
+					oldNode := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(oldNode), oldNode)).To(Succeed())
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetAnnotations()).To(HaveKeyWithValue(resources.NodeUIDAnnotationKey, string(oldNode.UID)))
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].UID).To(Equal(oldNode.UID))
+
+					By("replacing the node")
+					Expect(k8sClient.Delete(context.Background(), oldNode)).To(Succeed())
+					replacement := newNode("unhealthy-worker-node-1", v1.NodeReady, v1.ConditionFalse, time.Minute*10, false)
+					Expect(k8sClient.Create(context.Background(), replacement)).To(Succeed())
+					Expect(replacement.GetUID()).ToNot(Equal(oldNode.UID))
+					time.Sleep(2 * time.Second)
+
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(cr.GetAnnotations()).To(HaveKeyWithValue(resources.NodeUIDAnnotationKey, string(replacement.GetUID())))
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].UID).To(Equal(replacement.GetUID()))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(cr.GetUID()))
+				})
 			})
 
-			When("few nodes become healthy", func() {
+			Context("a remediated node isn't selected anymore", func() {
+				deselectNode := func() {
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					delete(node.Labels, utils.WorkerRoleLabel)
+					Expect(k8sClient.Update(context.Background(), node)).To(Succeed())
+					// give the reconciler some time
+					time.Sleep(2 * time.Second)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+				}
+
 				BeforeEach(func() {
+					underTest.Spec.Selector = metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      utils.WorkerRoleLabel,
+							Operator: metav1.LabelSelectorOpExists,
+						}},
+					}
 					setupObjects(1, 2)
-					remediationCR := newRemediationCR("healthy-worker-node-2", underTest)
-					remediationCROther := newRemediationCR("healthy-worker-node-1", underTest)
-					refs := remediationCROther.GetOwnerReferences()
-					refs[0].Name = "other"
-					remediationCROther.SetOwnerReferences(refs)
-					objects = append(objects, remediationCR, remediationCROther)
 				})
 
-				It("deletes an existing remediation CR and updates status", func() {
-					cr := newRemediationCR("healthy-worker-node-2", underTest)
-					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-					Expect(errors.IsNotFound(err)).To(BeTrue())
+				When("the deselected node policy is Finish", func() {
+					It("keeps the remediation CR until the node is healthy", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						By("deselecting the node")
+						deselectNode()
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+						Expect(underTest.Status.ObservedNodes).To(Equal(2))
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+
+						By("making the node healthy")
+						node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unhealthy-worker-node-1"}}
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+						node.Status.Conditions[0].Status = v1.ConditionTrue
+						Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+						time.Sleep(2 * time.Second)
+
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+						Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					})
+				})
 
-					// owned by other NHC, should not be deleted
-					cr = newRemediationCR("healthy-worker-node-1", underTest)
-					err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-					Expect(err).NotTo(HaveOccurred())
+				When("the deselected node policy is Cancel", func() {
+					BeforeEach(func() {
+						underTest.Spec.DeselectedNodePolicy = v1alpha1.DeselectedNodePolicyCancel
+					})
 
-					cr = newRemediationCR("unhealthy-worker-node-1", underTest)
-					err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
-					Expect(err).NotTo(HaveOccurred())
+					It("deletes the remediation CR and cleans up status", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						By("deselecting the node")
+						deselectNode()
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+						Expect(underTest.Status.ObservedNodes).To(Equal(2))
+						Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
+						Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+					})
 
-					Expect(underTest.Status.HealthyNodes).To(Equal(2))
-					Expect(underTest.Status.ObservedNodes).To(Equal(3))
-					Expect(underTest.Status.InFlightRemediations).To(HaveLen(1))
-					Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
-					Expect(underTest.Status.UnhealthyNodes[0].Name).To(Equal(cr.GetName()))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations).To(HaveLen(1))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.GroupVersionKind()).To(Equal(cr.GroupVersionKind()))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Name).To(Equal(cr.GetName()))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.Namespace).To(Equal(cr.GetNamespace()))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Resource.UID).To(Equal(cr.GetUID()))
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].Started).ToNot(BeNil())
-					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).To(BeNil())
-					Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseRemediating))
-					Expect(underTest.Status.Reason).ToNot(BeEmpty())
+					It("keeps the remediation CR while the NHC is paused", func() {
+						cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+						By("pausing the NHC")
+						underTest.Spec.PauseRequests = []string{"test"}
+						Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+						time.Sleep(1 * time.Second)
+
+						By("deselecting the node")
+						deselectNode()
+						Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+						Expect(underTest.Status.UnhealthyNodes).To(HaveLen(1))
+
+						By("unpausing the NHC")
+						underTest.Spec.PauseRequests = nil
+						Expect(k8sClient.Update(context.Background(), underTest)).To(Succeed())
+						time.Sleep(2 * time.Second)
+						err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+						Expect(errors.IsNotFound(err)).To(BeTrue())
+					})
 				})
 			})
 
@@ -412,6 +1404,20 @@ var _ = Describe("Node Health Check CR", func() {
 					Expect(underTest.Status.InFlightRemediations).To(BeEmpty())
 					Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
 				})
+
+				When("a remediation budget exists", func() {
+					BeforeEach(func() {
+						budget := newRemediationBudget(pointer.Int(1))
+						objects = append([]client.Object{budget}, objects...)
+					})
+
+					It("releases the reserved budget", func() {
+						Expect(underTest.Status.UnhealthyNodes).To(BeEmpty())
+						budget := &v1alpha1.RemediationBudget{}
+						Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: v1alpha1.RemediationBudgetName}, budget)).To(Succeed())
+						Expect(budget.Status.Remediations).To(BeEmpty())
+					})
+				})
 			})
 		}
 
@@ -543,6 +1549,80 @@ var _ = Describe("Node Health Check CR", func() {
 				Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseEnabled))
 
 			})
+
+			When("the remediation rate limit is exceeded", func() {
+				BeforeEach(func() {
+					underTest.Spec.RemediationRateLimit = &v1alpha1.RemediationRateLimit{
+						MaxRemediations: 1,
+						Window:          metav1.Duration{Duration: time.Hour},
+					}
+				})
+
+				It("counts escalations and queues them", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					Expect(underTest.Status.RemediationRateLimit.RecentRemediations).To(HaveLen(1))
+
+					By("waiting for the 1st remediation to time out")
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.RemediationRateLimit.QueuedNodes).To(ConsistOf("unhealthy-worker-node-1"))
+					}, "10s", "250ms").Should(Succeed())
+					Expect(underTest.Status.UnhealthyNodes[0].Remediations[0].TimedOut).ToNot(BeNil())
+					Expect(underTest.Status.RemediationRateLimit.NextRemediationAt).ToNot(BeNil())
+
+					cr = newRemediationCRForSecondRemediation("unhealthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the failure domain of a remediated node doesn't have enough healthy nodes anymore", func() {
+				zoneLabel := "topology.kubernetes.io/zone"
+
+				BeforeEach(func() {
+					underTest.Spec.Topology = &v1alpha1.Topology{Key: zoneLabel}
+					setupObjects(1, 5)
+					for _, o := range objects {
+						if node, ok := o.(*v1.Node); ok {
+							switch node.Name {
+							case "unhealthy-worker-node-1", "healthy-worker-node-1", "healthy-worker-node-2":
+								node.Labels[zoneLabel] = "zone-a"
+							default:
+								node.Labels[zoneLabel] = "zone-b"
+							}
+						}
+					}
+				})
+
+				It("keeps escalating the ongoing remediation", func() {
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+					By("making another node of the failure domain unhealthy")
+					node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "healthy-worker-node-1"}}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(node), node)).To(Succeed())
+					node.Status.Conditions[0].Status = v1.ConditionFalse
+					node.Status.Conditions[0].LastTransitionTime = metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+					Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+
+					By("waiting for the escalation")
+					cr = newRemediationCRForSecondRemediation("unhealthy-worker-node-1", underTest)
+					Eventually(func() error {
+						return k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+					}, "10s", "250ms").Should(Succeed())
+
+					newCR := newRemediationCR("healthy-worker-node-1", underTest)
+					err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(newCR), newCR)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					Expect(underTest.Status.Conditions).To(ContainElement(
+						And(
+							HaveField("Type", v1alpha1.ConditionTypeRemediationBlocked),
+							HaveField("Reason", v1alpha1.ConditionReasonMinHealthyNotMet),
+						)))
+				})
+			})
 		})
 
 		Context("with progressing condition being set", func() {
@@ -816,6 +1896,45 @@ var _ = Describe("Node Health Check CR", func() {
 				Expect(requests).To(ContainElement(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest2.GetName()}}))
 			})
 		})
+		When("two NHCs select the same nodes", func() {
+			BeforeEach(func() {
+				objects = newNodes(0, 2, false)
+				underTest1 = newNodeHealthCheck()
+				underTest2 = newNodeHealthCheck()
+				underTest2.Name = "test-2"
+				objects = append(objects, underTest1, underTest2)
+			})
+
+			It("creates a reconcile request for the other NHC", func() {
+				handler := utils.NHCByOtherNHCMapperFunc(k8sClient, controllerruntime.Log)
+				requests := handler(underTest1)
+				Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: underTest2.GetName()}}))
+			})
+
+			It("sets the SelectorOverlap condition on both NHCs", func() {
+				for _, nhc := range []*v1alpha1.NodeHealthCheck{underTest1, underTest2} {
+					other := underTest2.GetName()
+					if nhc == underTest2 {
+						other = underTest1.GetName()
+					}
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(nhc), nhc)).To(Succeed())
+						g.Expect(nhc.Status.Conditions).To(ContainElement(
+							And(
+								HaveField("Type", v1alpha1.ConditionTypeSelectorOverlap),
+								HaveField("Status", metav1.ConditionTrue),
+								HaveField("Reason", v1alpha1.ConditionReasonSelectorOverlapDetected),
+								HaveField("Message", And(
+									ContainSubstring(other),
+									ContainSubstring("healthy-worker-node-1"),
+									ContainSubstring("healthy-worker-node-2"),
+								)),
+							)))
+					}, "5s", "500ms").Should(Succeed())
+				}
+			})
+		})
+
 		When("a node changes status and there are no NHC objects", func() {
 			BeforeEach(func() {
 				objects = newNodes(3, 10, false)
@@ -832,6 +1951,206 @@ var _ = Describe("Node Health Check CR", func() {
 		})
 	})
 
+	Context("Deletion", func() {
+		var (
+			underTest *v1alpha1.NodeHealthCheck
+			objects   []client.Object
+		)
+
+		BeforeEach(func() {
+			underTest = newNodeHealthCheck()
+			objects = newNodes(1, 2, false)
+		})
+
+		JustBeforeEach(func() {
+			objects = append(objects, underTest)
+			createObjects(objects...)
+			// give the reconciler some time to add the finalizer and to create the remediation CR
+			time.Sleep(2 * time.Second)
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+			Expect(underTest.Finalizers).To(ContainElement(nhcFinalizer))
+			cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+
+			Expect(k8sClient.Delete(context.Background(), underTest)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			deleteObjects(objects...)
+			cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+			_ = k8sClient.Delete(context.Background(), cr)
+			time.Sleep(1 * time.Second)
+		})
+
+		When("deletion policy is Wait", func() {
+			It("waits for ongoing remediations", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+					g.Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseTerminating))
+				}, "3s", "500ms").Should(Succeed())
+
+				By("making the node healthy")
+				node := &v1.Node{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "unhealthy-worker-node-1"}, node)).To(Succeed())
+				node.Status.Conditions[0].Status = v1.ConditionTrue
+				node.Status.Conditions[0].LastTransitionTime = metav1.Now()
+				Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+
+				ensureNHCDeleted(underTest)
+			})
+
+			When("the healthy stabilization window is configured", func() {
+				BeforeEach(func() {
+					underTest.Spec.HealthyStabilizationWindow = &metav1.Duration{Duration: 5 * time.Second}
+				})
+
+				It("waits for the remediation of a node which turns healthy within the window", func() {
+					By("making the node healthy")
+					node := &v1.Node{}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "unhealthy-worker-node-1"}, node)).To(Succeed())
+					node.Status.Conditions[0].Status = v1.ConditionTrue
+					node.Status.Conditions[0].LastTransitionTime = metav1.Now()
+					Expect(k8sClient.Status().Update(context.Background(), node)).To(Succeed())
+
+					cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+					Consistently(func(g Gomega) {
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(underTest), underTest)).To(Succeed())
+						g.Expect(underTest.Status.Phase).To(Equal(v1alpha1.PhaseTerminating))
+						g.Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+					}, "3s", "500ms").Should(Succeed())
+
+					ensureNHCDeleted(underTest)
+				})
+			})
+		})
+
+		When("deletion policy is Cancel", func() {
+			BeforeEach(func() {
+				underTest.Spec.DeletionPolicy = v1alpha1.DeletionPolicyCancel
+			})
+
+			It("deletes the remediation CR", func() {
+				ensureNHCDeleted(underTest)
+				cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("deletion policy is Orphan", func() {
+			BeforeEach(func() {
+				underTest.Spec.DeletionPolicy = v1alpha1.DeletionPolicyOrphan
+			})
+
+			It("removes the owner reference from the remediation CR", func() {
+				ensureNHCDeleted(underTest)
+				cr := newRemediationCR("unhealthy-worker-node-1", underTest)
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+				Expect(cr.GetOwnerReferences()).To(BeEmpty())
+			})
+		})
+
+		When("the NHC holds a reservation of the remediation budget", func() {
+			BeforeEach(func() {
+				// orphaned remediation CRs still exist, but don't count in the budget anymore
+				underTest.Spec.DeletionPolicy = v1alpha1.DeletionPolicyOrphan
+				objects = append([]client.Object{newRemediationBudget(pointer.Int(10))}, objects...)
+			})
+
+			It("releases the reservation", func() {
+				ensureNHCDeleted(underTest)
+				budget := &v1alpha1.RemediationBudget{}
+				Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: v1alpha1.RemediationBudgetName}, budget)).To(Succeed())
+				Expect(budget.Status.Remediations).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Lease updates", func() {
+		var oldLease, newLease *coordinationv1.Lease
+
+		BeforeEach(func() {
+			now := time.Now()
+			oldLease = newNodeLease("node", now.Add(-10*time.Second))
+			newLease = newNodeLease("node", now)
+		})
+
+		When("a lease is renewed regularly", func() {
+			It("should not request reconcile", func() {
+				Expect(leaseUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldLease, ObjectNew: newLease})).To(BeFalse())
+			})
+		})
+
+		When("a stale lease is renewed", func() {
+			BeforeEach(func() {
+				oldLease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-2 * time.Minute)}
+			})
+			It("should request reconcile", func() {
+				Expect(leaseUpdateNeedsReconcile(event.UpdateEvent{ObjectOld: oldLease, ObjectNew: newLease})).To(BeTrue())
+			})
+		})
+
+		When("a lease is in the node lease namespace", func() {
+			It("should be handled", func() {
+				Expect(isNodeLease(newLease)).To(BeTrue())
+			})
+		})
+
+		When("a lease is in another namespace", func() {
+			BeforeEach(func() {
+				newLease.Namespace = "default"
+			})
+			It("should be ignored", func() {
+				Expect(isNodeLease(newLease)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Remediation rate limit", func() {
+		var nhc *v1alpha1.NodeHealthCheck
+		now := time.Now()
+
+		BeforeEach(func() {
+			nhc = newNodeHealthCheck()
+			nhc.Spec.RemediationRateLimit = &v1alpha1.RemediationRateLimit{
+				MaxRemediations: 2,
+				Window:          metav1.Duration{Duration: time.Hour},
+			}
+			nhc.Status.RemediationRateLimit = &v1alpha1.RemediationRateLimitStatus{}
+		})
+
+		When("no remediation CRs were created", func() {
+			It("doesn't return the next remediation time", func() {
+				Expect(getNextRemediationAt(nhc)).To(BeNil())
+			})
+		})
+
+		When("remediation CRs aren't sorted by creation time", func() {
+			BeforeEach(func() {
+				nhc.Status.RemediationRateLimit.RecentRemediations = []metav1.Time{
+					metav1.NewTime(now.Add(-10 * time.Minute)),
+					metav1.NewTime(now.Add(-30 * time.Minute)),
+				}
+			})
+			It("returns when the oldest CR leaves the window", func() {
+				Expect(getNextRemediationAt(nhc).Time).To(BeTemporally("==", now.Add(30*time.Minute)))
+			})
+		})
+
+		When("more remediation CRs were created than allowed", func() {
+			BeforeEach(func() {
+				nhc.Status.RemediationRateLimit.RecentRemediations = []metav1.Time{
+					metav1.NewTime(now.Add(-10 * time.Minute)),
+					metav1.NewTime(now.Add(-20 * time.Minute)),
+					metav1.NewTime(now.Add(-30 * time.Minute)),
+				}
+			})
+			It("returns when enough CRs left the window", func() {
+				Expect(getNextRemediationAt(nhc).Time).To(BeTemporally("==", now.Add(40*time.Minute)))
+			})
+		})
+	})
+
 	Context("Node updates", func() {
 		var oldConditions []v1.NodeCondition
 		var newConditions []v1.NodeCondition
@@ -964,6 +2283,29 @@ var _ = Describe("Node Health Check CR", func() {
 				Expect(conditionsNeedReconcile(oldConditions, newConditions)).To(BeTrue())
 			})
 		})
+
+		Context("taints", func() {
+			unreachable := v1.Taint{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}
+			custom := v1.Taint{Key: "vendor.example.com/unhealthy", Value: "disk", Effect: v1.TaintEffectNoSchedule}
+
+			It("should not request reconcile for equal taints", func() {
+				Expect(taintsNeedReconcile([]v1.Taint{unreachable, custom}, []v1.Taint{custom, unreachable})).To(BeFalse())
+			})
+
+			It("should request reconcile for added taints", func() {
+				Expect(taintsNeedReconcile([]v1.Taint{custom}, []v1.Taint{custom, unreachable})).To(BeTrue())
+			})
+
+			It("should request reconcile for removed taints", func() {
+				Expect(taintsNeedReconcile([]v1.Taint{custom, unreachable}, []v1.Taint{custom})).To(BeTrue())
+			})
+
+			It("should request reconcile for changed taint values", func() {
+				changed := custom
+				changed.Value = "network"
+				Expect(taintsNeedReconcile([]v1.Taint{custom}, []v1.Taint{changed})).To(BeTrue())
+			})
+		})
 	})
 })
 
@@ -1040,6 +2382,73 @@ func newNodeHealthCheck() *v1alpha1.NodeHealthCheck {
 	}
 }
 
+func newNodeLease(nodeName string, renewTime time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeName,
+			Namespace: v1.NamespaceNodeLease,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       pointer.String(nodeName),
+			LeaseDurationSeconds: pointer.Int32(40),
+			RenewTime:            &metav1.MicroTime{Time: renewTime},
+		},
+	}
+}
+
+func newNodeHealthSignal(name string, nodeName string, source string, severity v1alpha1.SignalSeverity, expiresAt time.Time) *v1alpha1.NodeHealthSignal {
+	return &v1alpha1.NodeHealthSignal{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1alpha1.NodeHealthSignalSpec{
+			NodeName:  nodeName,
+			Source:    source,
+			Severity:  severity,
+			ExpiresAt: metav1.Time{Time: expiresAt},
+		},
+	}
+}
+
+func newRemediationBudget(maxConcurrentRemediations *int) *v1alpha1.RemediationBudget {
+	return &v1alpha1.RemediationBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: v1alpha1.RemediationBudgetName,
+		},
+		Spec: v1alpha1.RemediationBudgetSpec{
+			MaxConcurrentRemediations: maxConcurrentRemediations,
+		},
+	}
+}
+
+func newPod(name string, nodeName string, ready v1.ConditionStatus, since time.Time) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"app": "cni"},
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{
+				{
+					Name:  "test",
+					Image: "test",
+				},
+			},
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:               v1.PodReady,
+					Status:             ready,
+					LastTransitionTime: metav1.Time{Time: since},
+				},
+			},
+		},
+	}
+}
+
 func newNodes(unhealthy int, healthy int, isControlPlane bool) []client.Object {
 	o := make([]client.Object, 0, healthy+unhealthy)
 	roleName := "-worker"
@@ -1080,3 +2489,11 @@ func newNode(name string, t v1.NodeConditionType, s v1.ConditionStatus, d time.D
 		},
 	}
 }
+
+// ensureNHCDeleted waits until the controller removed the finalizer and the NHC is gone
+func ensureNHCDeleted(nhc *v1alpha1.NodeHealthCheck) {
+	Eventually(func() bool {
+		err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(nhc), &v1alpha1.NodeHealthCheck{})
+		return errors.IsNotFound(err)
+	}, "30s", "250ms").Should(BeTrue())
+}
@@ -0,0 +1,172 @@
+package prometheus
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ServiceAccountTokenFile is the path of the operator's service account token
+	ServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	queryPath      = "/api/v1/query"
+	requestTimeout = 10 * time.Second
+	vectorResult   = "vector"
+	successStatus  = "success"
+)
+
+// Query defines a PromQL query and how to run it
+type Query struct {
+	// URL is the base URL of the Prometheus compatible HTTP API
+	URL string
+	// Query is the PromQL query, which needs to return an instant vector
+	Query string
+	// InsecureSkipTLSVerify disables verification of the server's certificate
+	InsecureSkipTLSVerify bool
+	// BearerTokenFile is an optional file containing a token for authentication
+	BearerTokenFile string
+}
+
+// Sample is a single series of a query result
+type Sample struct {
+	Metric map[string]string
+	Value  float64
+}
+
+// Client runs queries against Prometheus compatible HTTP APIs, and caches their results
+type Client struct {
+	lock  sync.Mutex
+	cache map[Query]cacheEntry
+	now   func() time.Time
+}
+
+type cacheEntry struct {
+	samples   []Sample
+	fetchedAt time.Time
+}
+
+// NewClient returns a new Client
+func NewClient() *Client {
+	return &Client{
+		cache: make(map[Query]cacheEntry),
+		now:   time.Now,
+	}
+}
+
+// Query runs the given query, or returns the cached result if it isn't older than maxAge.
+// Failed queries aren't cached.
+func (c *Client) Query(ctx context.Context, q Query, maxAge time.Duration) ([]Sample, error) {
+	c.lock.Lock()
+	entry, exists := c.cache[q]
+	c.lock.Unlock()
+	if exists && c.now().Sub(entry.fetchedAt) < maxAge {
+		return entry.samples, nil
+	}
+
+	samples, err := runQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.cache[q] = cacheEntry{
+		samples:   samples,
+		fetchedAt: c.now(),
+	}
+	c.lock.Unlock()
+	return samples, nil
+}
+
+// queryResponse is the response of the Prometheus instant query API
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			// Value is a [timestamp, "value"] tuple
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func runQuery(ctx context.Context, q Query) ([]Sample, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	reqURL := strings.TrimSuffix(q.URL, "/") + queryPath + "?" + url.Values{"query": []string{q.Query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if q.BearerTokenFile != "" {
+		token, err := os.ReadFile(q.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: q.InsecureSkipTLSVerify},
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	return parseResponse(resp.StatusCode, body)
+}
+
+func parseResponse(statusCode int, body []byte) ([]Sample, error) {
+	response := &queryResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("failed to parse response with HTTP status %d: %v", statusCode, err)
+	}
+	if response.Status != successStatus {
+		return nil, fmt.Errorf("query failed with HTTP status %d: %s: %s", statusCode, response.ErrorType, response.Error)
+	}
+	if response.Data.ResultType != vectorResult {
+		return nil, fmt.Errorf("query needs to return a %s, but returned a %s", vectorResult, response.Data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(response.Data.Result))
+	for _, result := range response.Data.Result {
+		if len(result.Value) != 2 {
+			return nil, fmt.Errorf("unexpected sample value %v", result.Value)
+		}
+		valueString, ok := result.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected sample value %v", result.Value[1])
+		}
+		value, err := strconv.ParseFloat(valueString, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sample value: %v", err)
+		}
+		samples = append(samples, Sample{
+			Metric: result.Metric,
+			Value:  value,
+		})
+	}
+	return samples, nil
+}
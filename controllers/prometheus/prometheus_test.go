@@ -0,0 +1,147 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const vectorResponse = `{
+  "status": "success",
+  "data": {
+    "resultType": "vector",
+    "result": [
+      {"metric": {"node": "node-1"}, "value": [1680000000.123, "0.95"]},
+      {"metric": {"node": "node-2"}, "value": [1680000000.123, "0.1"]}
+    ]
+  }
+}`
+
+var _ = Describe("Prometheus query", func() {
+
+	var (
+		server      *httptest.Server
+		response    string
+		statusCode  int
+		requests    int
+		lastRequest *http.Request
+		client      *Client
+		now         time.Time
+		query       Query
+		samples     []Sample
+		queryErr    error
+		maxAge      time.Duration
+	)
+
+	BeforeEach(func() {
+		response = vectorResponse
+		statusCode = http.StatusOK
+		requests = 0
+		lastRequest = nil
+		maxAge = time.Minute
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			lastRequest = r
+			w.WriteHeader(statusCode)
+			_, _ = fmt.Fprint(w, response)
+		}))
+		DeferCleanup(server.Close)
+
+		now = time.Now()
+		client = NewClient()
+		client.now = func() time.Time { return now }
+		query = Query{
+			URL:   server.URL,
+			Query: `node_cpu_utilisation > 0.9`,
+		}
+	})
+
+	JustBeforeEach(func() {
+		samples, queryErr = client.Query(context.Background(), query, maxAge)
+	})
+
+	When("the query succeeds", func() {
+		It("returns the samples", func() {
+			Expect(queryErr).ToNot(HaveOccurred())
+			Expect(samples).To(ConsistOf(
+				Sample{Metric: map[string]string{"node": "node-1"}, Value: 0.95},
+				Sample{Metric: map[string]string{"node": "node-2"}, Value: 0.1},
+			))
+			Expect(lastRequest.URL.Path).To(Equal(queryPath))
+			Expect(lastRequest.URL.Query().Get("query")).To(Equal(query.Query))
+		})
+
+		It("caches the result for the given max age", func() {
+			_, err := client.Query(context.Background(), query, maxAge)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+
+			now = now.Add(maxAge)
+			_, err = client.Query(context.Background(), query, maxAge)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+
+		It("doesn't share the cache between queries", func() {
+			otherQuery := query
+			otherQuery.Query = `node_memory_utilisation > 0.9`
+			_, err := client.Query(context.Background(), otherQuery, maxAge)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+	})
+
+	When("a bearer token file is configured", func() {
+		BeforeEach(func() {
+			tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(tokenFile, []byte("secret\n"), 0600)).To(Succeed())
+			query.BearerTokenFile = tokenFile
+		})
+
+		It("sends the token", func() {
+			Expect(queryErr).ToNot(HaveOccurred())
+			Expect(lastRequest.Header.Get("Authorization")).To(Equal("Bearer secret"))
+		})
+	})
+
+	When("the query fails", func() {
+		BeforeEach(func() {
+			statusCode = http.StatusBadRequest
+			response = `{"status": "error", "errorType": "bad_data", "error": "parse error"}`
+		})
+
+		It("returns an error and doesn't cache it", func() {
+			Expect(queryErr).To(MatchError(ContainSubstring("parse error")))
+			_, err := client.Query(context.Background(), query, maxAge)
+			Expect(err).To(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+	})
+
+	When("the query doesn't return a vector", func() {
+		BeforeEach(func() {
+			response = `{"status": "success", "data": {"resultType": "scalar", "result": [1680000000.123, "1"]}}`
+		})
+
+		It("returns an error", func() {
+			Expect(queryErr).To(HaveOccurred())
+		})
+	})
+
+	When("the server isn't reachable", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("returns an error", func() {
+			Expect(queryErr).To(HaveOccurred())
+		})
+	})
+})
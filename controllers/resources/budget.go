@@ -0,0 +1,228 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// RemediationBudgetExhaustedError is returned when the RemediationBudget doesn't allow a new remediation
+type RemediationBudgetExhaustedError struct{ msg string }
+
+func (rb RemediationBudgetExhaustedError) Error() string { return rb.msg }
+
+// ReserveRemediationBudget checks if the RemediationBudget allows remediating the given node with a template of the
+// given kind, and counts the remediation in the budget's status. Updating the status fails on concurrent
+// modifications, so the budget is checked atomically.
+func (m *manager) ReserveRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, templateKind string) error {
+	budget, err := m.getRemediationBudget()
+	if err != nil || budget == nil {
+		return err
+	}
+
+	existingNHCs, err := m.getExistingNHCs()
+	if err != nil {
+		return err
+	}
+
+	// ignore remediations of deleted NHCs, and of the given node
+	var others []remediationv1alpha1.BudgetedRemediation
+	var current *remediationv1alpha1.BudgetedRemediation
+	for i, remediation := range budget.Status.Remediations {
+		if _, exists := existingNHCs[remediation.NodeHealthCheck]; !exists {
+			continue
+		}
+		if remediation.NodeHealthCheck == nhc.Name && remediation.NodeName == nodeName {
+			current = &budget.Status.Remediations[i]
+			continue
+		}
+		others = append(others, remediation)
+	}
+	if current != nil && current.TemplateKind == templateKind {
+		return nil
+	}
+
+	// escalated remediations are counted by the global limit already
+	if max := budget.Spec.MaxConcurrentRemediations; current == nil && max != nil && len(others) >= *max {
+		return RemediationBudgetExhaustedError{msg: fmt.Sprintf("%d nodes are remediated by all NodeHealthChecks, which is the maximum of %d", len(others), *max)}
+	}
+	for _, limit := range budget.Spec.TemplateKindLimits {
+		if limit.Kind != templateKind {
+			continue
+		}
+		count := 0
+		for _, remediation := range others {
+			if remediation.TemplateKind == templateKind {
+				count++
+			}
+		}
+		if count >= limit.MaxConcurrentRemediations {
+			return RemediationBudgetExhaustedError{msg: fmt.Sprintf("%d nodes are remediated with %s by all NodeHealthChecks, which is the maximum of %d", count, templateKind, limit.MaxConcurrentRemediations)}
+		}
+	}
+
+	budget.Status.Remediations = append(others, remediationv1alpha1.BudgetedRemediation{
+		NodeHealthCheck: nhc.Name,
+		NodeName:        nodeName,
+		TemplateKind:    templateKind,
+	})
+	return m.updateRemediationBudgetStatus(budget)
+}
+
+// ReleaseRemediationBudget releases the reservation of the given node, when its remediation couldn't be started.
+// When the node is still remediated with another template, the budget counts that remediation again.
+func (m *manager) ReleaseRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) error {
+	budget, err := m.getRemediationBudget()
+	if err != nil || budget == nil {
+		return err
+	}
+
+	templateKind, isRemediated := GetStatusTemplateKinds(nhc)[nodeName]
+	changed := false
+	var released []remediationv1alpha1.BudgetedRemediation
+	for _, remediation := range budget.Status.Remediations {
+		if remediation.NodeHealthCheck == nhc.Name && remediation.NodeName == nodeName {
+			if !isRemediated {
+				changed = true
+				continue
+			}
+			if remediation.TemplateKind != templateKind {
+				remediation.TemplateKind = templateKind
+				changed = true
+			}
+		}
+		released = append(released, remediation)
+	}
+
+	if !changed {
+		return nil
+	}
+	budget.Status.Remediations = released
+	return m.updateRemediationBudgetStatus(budget)
+}
+
+// SyncRemediationBudget updates the remediations of the given NHC in the RemediationBudget's status, based on the
+// given template kinds by node name. This releases the budget of finished remediations, and counts remediations which
+// were started before the budget existed. Remediations of deleted NHCs are released as well.
+func (m *manager) SyncRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, templateKinds map[string]string) error {
+	budget, err := m.getRemediationBudget()
+	if err != nil || budget == nil {
+		return err
+	}
+
+	existingNHCs, err := m.getExistingNHCs()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	counted := make(map[string]struct{}, len(templateKinds))
+	var synced []remediationv1alpha1.BudgetedRemediation
+	for _, remediation := range budget.Status.Remediations {
+		if remediation.NodeHealthCheck != nhc.Name {
+			if _, exists := existingNHCs[remediation.NodeHealthCheck]; !exists {
+				changed = true
+				continue
+			}
+			synced = append(synced, remediation)
+			continue
+		}
+		templateKind, isRemediated := templateKinds[remediation.NodeName]
+		if !isRemediated {
+			changed = true
+			continue
+		}
+		if remediation.TemplateKind != templateKind {
+			remediation.TemplateKind = templateKind
+			changed = true
+		}
+		counted[remediation.NodeName] = struct{}{}
+		synced = append(synced, remediation)
+	}
+
+	nodeNames := make([]string, 0, len(templateKinds))
+	for nodeName := range templateKinds {
+		if _, isCounted := counted[nodeName]; !isCounted {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		synced = append(synced, remediationv1alpha1.BudgetedRemediation{
+			NodeHealthCheck: nhc.Name,
+			NodeName:        nodeName,
+			TemplateKind:    templateKinds[nodeName],
+		})
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	budget.Status.Remediations = synced
+	return m.updateRemediationBudgetStatus(budget)
+}
+
+// GetStatusTemplateKinds returns the template kinds of the current remediations of the given NHC by node name
+func GetStatusTemplateKinds(nhc *remediationv1alpha1.NodeHealthCheck) map[string]string {
+	templateKinds := make(map[string]string)
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode == nil || len(unhealthyNode.Remediations) == 0 {
+			continue
+		}
+		current := unhealthyNode.Remediations[len(unhealthyNode.Remediations)-1]
+		if current == nil {
+			continue
+		}
+		templateKinds[unhealthyNode.Name] = current.Resource.Kind + templateSuffix
+	}
+	return templateKinds
+}
+
+// getExistingNHCs returns the names of all existing NHCs
+func (m *manager) getExistingNHCs() (map[string]struct{}, error) {
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := m.List(m.ctx, nhcList); err != nil {
+		return nil, errors.Wrapf(err, "failed to list NHCs")
+	}
+	existingNHCs := make(map[string]struct{}, len(nhcList.Items))
+	for _, existingNHC := range nhcList.Items {
+		existingNHCs[existingNHC.Name] = struct{}{}
+	}
+	return existingNHCs, nil
+}
+
+// getRemediationBudget returns the RemediationBudget, or nil if it doesn't exist.
+// It's cached for the lifetime of the manager, which is a single reconcile, because the cache might not know about
+// own status updates yet.
+func (m *manager) getRemediationBudget() (*remediationv1alpha1.RemediationBudget, error) {
+	if m.budget != nil {
+		return m.budget, nil
+	}
+	budget := &remediationv1alpha1.RemediationBudget{}
+	if err := m.Get(m.ctx, types.NamespacedName{Name: remediationv1alpha1.RemediationBudgetName}, budget); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get remediation budget")
+	}
+	m.budget = budget
+	return budget, nil
+}
+
+func (m *manager) updateRemediationBudgetStatus(budget *remediationv1alpha1.RemediationBudget) error {
+	if err := m.Status().Update(m.ctx, budget); err != nil {
+		// get the latest version on the next try
+		m.budget = nil
+		return errors.Wrapf(err, "failed to update remediation budget status")
+	}
+	m.log.Info("updated remediation budget", "remediations", len(budget.Status.Remediations))
+	return nil
+}
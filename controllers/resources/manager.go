@@ -8,10 +8,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
@@ -25,6 +27,9 @@ import (
 const (
 	templateSuffix    = "Template"
 	machineAnnotation = "machine.openshift.io/machine"
+
+	// NodeUIDAnnotationKey is the annotation key for the UID of the remediated node on remediation CRs
+	NodeUIDAnnotationKey = "remediation.medik8s.io/node-uid"
 )
 
 type Manager interface {
@@ -35,9 +40,17 @@ type Manager interface {
 	GenerateRemediationCR(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, template *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	CreateRemediationCR(remediationCR *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) (bool, error)
 	DeleteRemediationCR(remediationCR *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) (bool, error)
+	OrphanRemediationCR(remediationCR *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) (bool, error)
 	UpdateRemediationCR(remediationCR *unstructured.Unstructured) error
 	ListRemediationCRs(nhc *remediationv1alpha1.NodeHealthCheck, remediationCRFilter func(r unstructured.Unstructured) bool) ([]unstructured.Unstructured, error)
 	GetNodes(labelSelector metav1.LabelSelector) ([]corev1.Node, error)
+	GetOverlappingNHCs(nhc *remediationv1alpha1.NodeHealthCheck, nodes []corev1.Node) (map[string][]string, error)
+	GetNodeLeases() (map[string]coordinationv1.Lease, error)
+	GetPods(namespace string, labelSelector *metav1.LabelSelector) ([]corev1.Pod, error)
+	GetNodeHealthSignals() ([]remediationv1alpha1.NodeHealthSignal, error)
+	ReserveRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string, templateKind string) error
+	ReleaseRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) error
+	SyncRemediationBudget(nhc *remediationv1alpha1.NodeHealthCheck, templateKinds map[string]string) error
 }
 
 type RemediationCRNotOwned struct{ msg string }
@@ -49,6 +62,7 @@ type manager struct {
 	ctx         context.Context
 	log         logr.Logger
 	onOpenshift bool
+	budget      *remediationv1alpha1.RemediationBudget
 }
 
 var _ Manager = &manager{}
@@ -77,6 +91,10 @@ func (m *manager) GenerateRemediationCR(node *corev1.Node, nhc *remediationv1alp
 	remediationCR.SetUID("")
 	remediationCR.SetSelfLink("")
 	remediationCR.SetCreationTimestamp(metav1.Now())
+	// remediation CRs are named like their node, the UID identifies nodes which were replaced with the same name
+	remediationCR.SetAnnotations(map[string]string{
+		NodeUIDAnnotationKey: string(node.UID),
+	})
 
 	owners := make([]metav1.OwnerReference, 0)
 	if nhc != nil {
@@ -187,6 +205,39 @@ func (m *manager) DeleteRemediationCR(remediationCR *unstructured.Unstructured,
 	return true, nil
 }
 
+// OrphanRemediationCR removes the owner reference to the given NHC from the remediation CR, so that it survives
+// the deletion of the NHC
+func (m *manager) OrphanRemediationCR(remediationCR *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) (bool, error) {
+
+	err := m.Get(context.Background(), client.ObjectKeyFromObject(remediationCR), remediationCR)
+	if err != nil && !apierrors.IsNotFound(err) {
+		// something went wrong
+		return false, errors.Wrapf(err, "failed to get remediation CR")
+	} else if apierrors.IsNotFound(err) || remediationCR.GetDeletionTimestamp() != nil {
+		// CR does not exist or is already deleted
+		// nothing to do
+		return false, nil
+	}
+
+	// also check if this is our CR
+	if !isOwner(remediationCR, nhc) {
+		return false, nil
+	}
+
+	var ownerRefs []metav1.OwnerReference
+	for _, owner := range remediationCR.GetOwnerReferences() {
+		if owner.Kind == nhc.Kind && owner.APIVersion == nhc.APIVersion && owner.Name == nhc.Name {
+			continue
+		}
+		ownerRefs = append(ownerRefs, owner)
+	}
+	remediationCR.SetOwnerReferences(ownerRefs)
+	if err = m.Update(context.Background(), remediationCR); err != nil {
+		return false, errors.Wrapf(err, "failed to remove owner reference from remediation CR")
+	}
+	return true, nil
+}
+
 func (m *manager) UpdateRemediationCR(remediationCR *unstructured.Unstructured) error {
 	return m.Update(m.ctx, remediationCR)
 }
@@ -241,6 +292,32 @@ func (m *manager) GetNodes(labelSelector metav1.LabelSelector) ([]corev1.Node, e
 	return nodes.Items, err
 }
 
+// GetOverlappingNHCs returns the names of the given nodes which are selected by other NHCs as well, keyed by the name
+// of the other NHC
+func (m *manager) GetOverlappingNHCs(nhc *remediationv1alpha1.NodeHealthCheck, nodes []corev1.Node) (map[string][]string, error) {
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := m.List(m.ctx, nhcList); err != nil {
+		return nil, errors.Wrapf(err, "failed to list NHCs")
+	}
+	overlaps := make(map[string][]string)
+	for _, other := range nhcList.Items {
+		if other.Name == nhc.Name {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&other.Spec.Selector)
+		if err != nil {
+			m.log.Error(err, "invalid node selector", "NHC name", other.Name)
+			continue
+		}
+		for _, node := range nodes {
+			if selector.Matches(labels.Set(node.GetLabels())) {
+				overlaps[other.Name] = append(overlaps[other.Name], node.Name)
+			}
+		}
+	}
+	return overlaps, nil
+}
+
 func isOwner(remediationCR *unstructured.Unstructured, nhc *remediationv1alpha1.NodeHealthCheck) bool {
 	for _, owner := range remediationCR.GetOwnerReferences() {
 		if owner.Kind == nhc.Kind && owner.APIVersion == nhc.APIVersion && owner.Name == nhc.Name {
@@ -276,3 +353,42 @@ func (m *manager) getOwningMachineWithNamespace(node *corev1.Node) (*metav1.Owne
 		BlockOwnerDeletion: pointer.Bool(false),
 	}, ns, nil
 }
+
+// GetNodeLeases returns the kubelet heartbeat Leases, by node name
+func (m *manager) GetNodeLeases() (map[string]coordinationv1.Lease, error) {
+	leaseList := &coordinationv1.LeaseList{}
+	if err := m.List(m.ctx, leaseList, client.InNamespace(corev1.NamespaceNodeLease)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list node leases")
+	}
+	leases := make(map[string]coordinationv1.Lease, len(leaseList.Items))
+	for _, lease := range leaseList.Items {
+		leases[lease.Name] = lease
+	}
+	return leases, nil
+}
+
+// GetPods returns the pods in the given namespace, optionally filtered by the given label selector
+func (m *manager) GetPods(namespace string, labelSelector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	listOptions := &client.ListOptions{Namespace: namespace}
+	if labelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed converting a selector from unhealthy pod selector")
+		}
+		listOptions.LabelSelector = selector
+	}
+	var pods corev1.PodList
+	if err := m.List(m.ctx, &pods, listOptions); err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods in namespace %s", namespace)
+	}
+	return pods.Items, nil
+}
+
+// GetNodeHealthSignals returns all NodeHealthSignals
+func (m *manager) GetNodeHealthSignals() ([]remediationv1alpha1.NodeHealthSignal, error) {
+	signalList := &remediationv1alpha1.NodeHealthSignalList{}
+	if err := m.List(m.ctx, signalList); err != nil {
+		return nil, errors.Wrapf(err, "failed to list node health signals")
+	}
+	return signalList.Items, nil
+}
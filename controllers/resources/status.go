@@ -33,6 +33,7 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
 		if unhealthyNode.Name == node.Name {
 			foundNode = true
+			unhealthyNode.UID = node.UID
 			foundRem := false
 			for _, rem := range unhealthyNode.Remediations {
 				if rem.Resource.GroupVersionKind() == remediationCR.GroupVersionKind() {
@@ -52,22 +53,88 @@ func UpdateStatusRemediationStarted(node *corev1.Node, nhc *remediationv1alpha1.
 	if !foundNode {
 		nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes, &remediationv1alpha1.UnhealthyNode{
 			Name:         node.GetName(),
+			UID:          node.GetUID(),
 			Remediations: []*remediationv1alpha1.Remediation{&remediation},
 		})
 	}
 
 }
 
+// UpdateStatusNodeHealthy removes the given node from the status. Nodes with healthy transitions within the flapping
+// detection window are kept, but their remediations are removed.
 func UpdateStatusNodeHealthy(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck) {
 	delete(nhc.Status.InFlightRemediations, node.GetName())
-	for i, _ := range nhc.Status.UnhealthyNodes {
+	for i := range nhc.Status.UnhealthyNodes {
 		if nhc.Status.UnhealthyNodes[i].Name == node.GetName() {
+			if len(nhc.Status.UnhealthyNodes[i].HealthyTransitions) > 0 {
+				nhc.Status.UnhealthyNodes[i].Remediations = nil
+				nhc.Status.UnhealthyNodes[i].KubeletProbe = nil
+				break
+			}
+			nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes[:i], nhc.Status.UnhealthyNodes[i+1:]...)
+			break
+		}
+	}
+}
+
+// UpdateStatusNodeRemoved removes the given node from the status, including its healthy transitions. It's used for
+// nodes which were deleted or which aren't selected anymore.
+func UpdateStatusNodeRemoved(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) {
+	delete(nhc.Status.InFlightRemediations, nodeName)
+	for i := range nhc.Status.UnhealthyNodes {
+		if nhc.Status.UnhealthyNodes[i] != nil && nhc.Status.UnhealthyNodes[i].Name == nodeName {
 			nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes[:i], nhc.Status.UnhealthyNodes[i+1:]...)
 			break
 		}
 	}
 }
 
+// UpdateStatusNodeReevaluated resets everything which was observed about the given node, except for its remediations.
+// Nodes without remediations are removed from the status.
+func UpdateStatusNodeReevaluated(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) {
+	for i := range nhc.Status.UnhealthyNodes {
+		unhealthyNode := nhc.Status.UnhealthyNodes[i]
+		if unhealthyNode == nil || unhealthyNode.Name != nodeName {
+			continue
+		}
+		if len(unhealthyNode.Remediations) == 0 {
+			nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes[:i], nhc.Status.UnhealthyNodes[i+1:]...)
+			return
+		}
+		unhealthyNode.KubeletProbe = nil
+		unhealthyNode.HealthySince = nil
+		unhealthyNode.HealthyTransitions = nil
+		unhealthyNode.FlappingSince = nil
+		return
+	}
+}
+
+// FindStatusUnhealthyNode returns the given node's entry in the NHC's status, or nil if it doesn't exist
+func FindStatusUnhealthyNode(nodeName string, nhc *remediationv1alpha1.NodeHealthCheck) *remediationv1alpha1.UnhealthyNode {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode != nil && unhealthyNode.Name == nodeName {
+			return unhealthyNode
+		}
+	}
+	return nil
+}
+
+// UpdateStatusKubeletProbe sets the kubelet probe result of the given node
+func UpdateStatusKubeletProbe(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, result *remediationv1alpha1.KubeletProbeResult) {
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode.Name == node.GetName() {
+			unhealthyNode.UID = node.GetUID()
+			unhealthyNode.KubeletProbe = result
+			return
+		}
+	}
+	nhc.Status.UnhealthyNodes = append(nhc.Status.UnhealthyNodes, &remediationv1alpha1.UnhealthyNode{
+		Name:         node.GetName(),
+		UID:          node.GetUID(),
+		KubeletProbe: result,
+	})
+}
+
 // FindStatusRemediation return the first remediation in the NHC's status for the given node which matches the remediationFilter
 func FindStatusRemediation(node *corev1.Node, nhc *remediationv1alpha1.NodeHealthCheck, remediationFilter func(r *remediationv1alpha1.Remediation) bool) *remediationv1alpha1.Remediation {
 	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
@@ -24,16 +24,110 @@ func NHCByNodeMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 	// are unrelated to this node. Its even possible that the node still doesn't
 	// have the right labels set to be picked up by the nhc selector.
 	delegate := func(o client.Object) []reconcile.Request {
-		requests := make([]reconcile.Request, 0)
+		all := func(_ *remediationv1alpha1.NodeHealthCheck) bool { return true }
+		// deleted nodes can't be fetched anymore, so use the node of the event when possible
+		if node, ok := o.(*v1.Node); ok {
+			return getNHCRequestsForNodeObject(c, logger, node, all)
+		}
+		return getNHCRequestsForNode(c, logger, o.GetName(), all)
+	}
+	return delegate
+}
 
-		node := &v1.Node{}
-		if err := c.Get(context.Background(), client.ObjectKey{Name: o.GetName()}, node); err != nil {
-			if !errors.IsNotFound(err) {
-				logger.Error(err, "mapper: failed to get node", "node name", o.GetName())
+// NHCByPodMapperFunc return the Pod-to-NHC mapper function
+func NHCByPodMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
+	// This closure is meant to fetch the NHCs which select the pod's node, and which are interested in the pod
+	delegate := func(o client.Object) []reconcile.Request {
+		pod, ok := o.(*v1.Pod)
+		if !ok || pod.Spec.NodeName == "" {
+			return make([]reconcile.Request, 0)
+		}
+		return getNHCRequestsForNode(c, logger, pod.Spec.NodeName, func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			for _, unhealthyPod := range nhc.Spec.UnhealthyPods {
+				if IsMatchingPod(unhealthyPod, pod) {
+					return true
+				}
 			}
-			return requests
+			return false
+		})
+	}
+	return delegate
+}
+
+// NHCByNodeHealthSignalMapperFunc return the NodeHealthSignal-to-NHC mapper function
+func NHCByNodeHealthSignalMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
+	// This closure is meant to fetch the NHCs which select the signal's node, and which are interested in the signal's source
+	delegate := func(o client.Object) []reconcile.Request {
+		signal, ok := o.(*remediationv1alpha1.NodeHealthSignal)
+		if !ok {
+			return make([]reconcile.Request, 0)
+		}
+		return getNHCRequestsForNode(c, logger, signal.Spec.NodeName, func(nhc *remediationv1alpha1.NodeHealthCheck) bool {
+			for _, unhealthySignal := range nhc.Spec.UnhealthySignals {
+				if unhealthySignal.Source == signal.Spec.Source {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return delegate
+}
+
+// getNHCRequestsForNode returns requests for all NHCs which select the given node, and which pass the given filter
+func getNHCRequestsForNode(c client.Client, logger logr.Logger, nodeName string, filter func(nhc *remediationv1alpha1.NodeHealthCheck) bool) []reconcile.Request {
+	requests := make([]reconcile.Request, 0)
+
+	node := &v1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: nodeName}, node); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "mapper: failed to get node", "node name", nodeName)
+		}
+		return requests
+	}
+	return getNHCRequestsForNodeObject(c, logger, node, filter)
+}
+
+// getNHCRequestsForNodeObject returns requests for all NHCs which select the given node or have it in their status,
+// and which pass the given filter
+func getNHCRequestsForNodeObject(c client.Client, logger logr.Logger, node *v1.Node, filter func(nhc *remediationv1alpha1.NodeHealthCheck) bool) []reconcile.Request {
+	requests := make([]reconcile.Request, 0)
+
+	nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+	if err := c.List(context.Background(), nhcList, &client.ListOptions{}); err != nil {
+		logger.Error(err, "mapper: failed to list NHCs")
+		return requests
+	}
+
+	for i := range nhcList.Items {
+		nhc := &nhcList.Items[i]
+		if !filter(nhc) {
+			continue
+		}
+		// nodes which aren't selected anymore need to be cleaned up from the status
+		if hasStatusNode(nhc, node.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nhc.GetName()}})
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector)
+		if err != nil {
+			logger.Error(err, "mapper: invalid node selector", "NHC name", nhc.GetName())
+			continue
 		}
 
+		if selector.Matches(labels.Set(node.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nhc.GetName()}})
+		}
+	}
+	return requests
+}
+
+// NHCByOtherNHCMapperFunc return the NHC-to-other-NHCs mapper function
+func NHCByOtherNHCMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
+	// This closure is meant to fetch all other NHCs, so that they can update their selector overlap status.
+	delegate := func(o client.Object) []reconcile.Request {
+		requests := make([]reconcile.Request, 0)
+
 		nhcList := &remediationv1alpha1.NodeHealthCheckList{}
 		if err := c.List(context.Background(), nhcList, &client.ListOptions{}); err != nil {
 			logger.Error(err, "mapper: failed to list NHCs")
@@ -41,13 +135,7 @@ func NHCByNodeMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 		}
 
 		for _, nhc := range nhcList.Items {
-			selector, err := metav1.LabelSelectorAsSelector(&nhc.Spec.Selector)
-			if err != nil {
-				logger.Error(err, "mapper: invalid node selector", "NHC name", nhc.GetName())
-				continue
-			}
-
-			if selector.Matches(labels.Set(node.GetLabels())) {
+			if nhc.GetName() != o.GetName() {
 				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nhc.GetName()}})
 			}
 		}
@@ -56,6 +144,29 @@ func NHCByNodeMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
 	return delegate
 }
 
+// NHCByRemediationBudgetMapperFunc return the RemediationBudget-to-NHC mapper function
+func NHCByRemediationBudgetMapperFunc(c client.Client, logger logr.Logger) handler.MapFunc {
+	// This closure is meant to fetch all NHCs, because the budget is shared by all of them
+	delegate := func(o client.Object) []reconcile.Request {
+		requests := make([]reconcile.Request, 0)
+		if o.GetName() != remediationv1alpha1.RemediationBudgetName {
+			return requests
+		}
+
+		nhcList := &remediationv1alpha1.NodeHealthCheckList{}
+		if err := c.List(context.Background(), nhcList, &client.ListOptions{}); err != nil {
+			logger.Error(err, "mapper: failed to list NHCs")
+			return requests
+		}
+
+		for _, nhc := range nhcList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: nhc.GetName()}})
+		}
+		return requests
+	}
+	return delegate
+}
+
 // NHCByRemediationCRMapperFunc return the RemediationCR-to-NHC mapper function
 func NHCByRemediationCRMapperFunc(logger logr.Logger) handler.MapFunc {
 	// This closure is meant to get the NHC for the given remediation CR
@@ -73,3 +184,15 @@ func NHCByRemediationCRMapperFunc(logger logr.Logger) handler.MapFunc {
 	}
 	return delegate
 }
+
+func hasStatusNode(nhc *remediationv1alpha1.NodeHealthCheck, nodeName string) bool {
+	if _, exists := nhc.Status.InFlightRemediations[nodeName]; exists {
+		return true
+	}
+	for _, unhealthyNode := range nhc.Status.UnhealthyNodes {
+		if unhealthyNode != nil && unhealthyNode.Name == nodeName {
+			return true
+		}
+	}
+	return false
+}
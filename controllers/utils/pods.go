@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	remediationv1alpha1 "github.com/medik8s/node-healthcheck-operator/api/v1alpha1"
+)
+
+// IsMatchingPod returns true if the given pod is selected by the given UnhealthyPod
+func IsMatchingPod(unhealthyPod remediationv1alpha1.UnhealthyPod, pod *v1.Pod) bool {
+	if pod.Namespace != unhealthyPod.Namespace {
+		return false
+	}
+	if unhealthyPod.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(unhealthyPod.Selector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			return false
+		}
+	}
+	if unhealthyPod.DaemonSet != "" {
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || owner.Kind != "DaemonSet" || owner.Name != unhealthyPod.DaemonSet {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPodNotReadySince returns since when the given pod isn't ready, or nil if it is ready
+func GetPodNotReadySince(pod *v1.Pod) *time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != v1.PodReady {
+			continue
+		}
+		if condition.Status == v1.ConditionTrue {
+			return nil
+		}
+		return &condition.LastTransitionTime.Time
+	}
+	// no ready condition yet
+	return &pod.CreationTimestamp.Time
+}
+
+// IsPodReady returns true if the given pod has a Ready condition with status true
+func IsPodReady(pod *v1.Pod) bool {
+	return GetPodNotReadySince(pod) == nil
+}
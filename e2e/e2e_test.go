@@ -279,10 +279,6 @@ var _ = Describe("e2e", func() {
 				}
 				Expect(k8sClient.Update(context.Background(), nhc)).To(MatchError(ContainSubstring(v1alpha1.OngoingRemediationError)), "selector update should be prevented")
 
-				By("ensuring config deletion fails")
-				nhc = getConfig(nhcName)
-				Expect(k8sClient.Delete(context.Background(), nhc)).To(MatchError(ContainSubstring(v1alpha1.OngoingRemediationError)), "deletion should be prevented")
-
 				By("ensuring minHealthy update succeeds")
 				nhc = getConfig(nhcName)
 				newValue := intstr.FromString("10%")
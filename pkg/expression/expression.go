@@ -0,0 +1,87 @@
+package expression
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// variable names available in expressions
+	conditionsVar = "conditions"
+	labelsVar     = "labels"
+	taintsVar     = "taints"
+	nowVar        = "now"
+)
+
+// Compile compiles the given CEL expression for evaluation against nodes.
+// The expression has access to these variables:
+// - conditions: a list of the node's conditions, with type, status, reason, message and lastTransitionTime fields
+// - labels: a map of the node's labels
+// - taints: a list of the node's taints, with key, value and effect fields
+// - now: the current time
+// The expression needs to return a bool.
+func Compile(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(conditionsVar, cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		cel.Variable(labelsVar, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(taintsVar, cel.ListType(cel.MapType(cel.StringType, cel.StringType))),
+		cel.Variable(nowVar, cel.TimestampType),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("expression must return a bool, but returns %s", outputType)
+	}
+	return env.Program(ast)
+}
+
+// Matches evaluates the compiled expression against the given node
+func Matches(program cel.Program, node *corev1.Node, now time.Time) (bool, error) {
+	conditions := make([]map[string]interface{}, 0, len(node.Status.Conditions))
+	for _, condition := range node.Status.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":               string(condition.Type),
+			"status":             string(condition.Status),
+			"reason":             condition.Reason,
+			"message":            condition.Message,
+			"lastTransitionTime": condition.LastTransitionTime.Time,
+		})
+	}
+	taints := make([]map[string]string, 0, len(node.Spec.Taints))
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, map[string]string{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": string(taint.Effect),
+		})
+	}
+	labels := node.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		conditionsVar: conditions,
+		labelsVar:     labels,
+		taintsVar:     taints,
+		nowVar:        now,
+	})
+	if err != nil {
+		return false, err
+	}
+	matches, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %v instead of a bool", result.Value())
+	}
+	return matches, nil
+}
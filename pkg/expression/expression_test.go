@@ -0,0 +1,78 @@
+package expression
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Unhealthy expressions", func() {
+
+	now := time.Now()
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node",
+			Labels: map[string]string{"zone": "a"},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{
+					Key:    "node.kubernetes.io/unreachable",
+					Effect: corev1.TaintEffectNoExecute,
+				},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeReady,
+					Status:             corev1.ConditionUnknown,
+					Reason:             "KubeletNotReady",
+					LastTransitionTime: metav1.NewTime(now.Add(-5 * time.Minute)),
+				},
+				{
+					Type:               corev1.NodeNetworkUnavailable,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-1 * time.Minute)),
+				},
+			},
+		},
+	}
+
+	DescribeTable("Compiling and evaluating",
+		func(expression string, expected bool) {
+			program, err := Compile(expression)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(Matches(program, node, now)).To(Equal(expected))
+		},
+		Entry("combined conditions",
+			`conditions.exists(c, c.type == "Ready" && c.status == "Unknown") && conditions.exists(c, c.type == "NetworkUnavailable" && c.status == "True")`,
+			true),
+		Entry("condition with duration",
+			`conditions.exists(c, c.type == "NetworkUnavailable" && c.status == "True" && now - c.lastTransitionTime > duration("2m"))`,
+			false),
+		Entry("condition reason",
+			`conditions.exists(c, c.type == "Ready" && c.reason.matches("^KubeletNotReady$"))`,
+			true),
+		Entry("labels",
+			`"zone" in labels && labels["zone"] == "b"`,
+			false),
+		Entry("taints",
+			`taints.exists(t, t.key == "node.kubernetes.io/unreachable" && t.effect == "NoExecute")`,
+			true),
+	)
+
+	DescribeTable("Compiling invalid expressions",
+		func(expression string) {
+			_, err := Compile(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("syntax error", `conditions.exists(c, `),
+		Entry("unknown variable", `foo == "bar"`),
+		Entry("not returning a bool", `labels["zone"]`),
+	)
+})
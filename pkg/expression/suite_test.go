@@ -0,0 +1,13 @@
+package expression
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestExpression(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Expression Suite")
+}